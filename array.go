@@ -0,0 +1,447 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	pg "github.com/lib/pq"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+)
+
+// ArrayElement is the constraint of the element of Array.
+type ArrayElement interface {
+	string | int64 | float64 | bool | time.Time | uuid.UUID
+}
+
+// Array represents a postgres array that may be null or not
+// present in JSON at all.
+//
+// Unlike StringArray, an empty array is a valid value, written as `{}` to the database.
+//
+// When using with bun ORM, do NOT use `type:...[]` or `array` tag on the field,
+// use nullzero tag and let AppendQuery handle the conversion:
+//
+//	type Model struct {
+//	    Scores IntArray `bun:"scores,nullzero"`
+//	}
+type Array[T ArrayElement] struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid array
+	Data    []T
+}
+
+type (
+	IntArray   = Array[int64]
+	FloatArray = Array[float64]
+	BoolArray  = Array[bool]
+	TimeArray  = Array[time.Time]
+	UUIDArray  = Array[uuid.UUID]
+)
+
+func NewArray[T ArrayElement](data []T, presentValid ...bool) Array[T] {
+	d := Array[T]{
+		Present: true,
+		Valid:   true,
+		Data:    data,
+	}
+
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewArrayPtr[T ArrayElement](data []T, presentValid ...bool) *Array[T] {
+	d := NewArray(data, presentValid...)
+	return &d
+}
+
+func (d Array[T]) IsPresent() bool {
+	return d.Present
+}
+
+func (d Array[T]) IsValid() bool {
+	return d.Valid
+}
+
+func (d Array[T]) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Array[T]) IsZero() bool {
+	return !d.Present
+}
+
+func (d Array[T]) Ptr() *[]T {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+var (
+	_ driver.Valuer                    = (*Array[int64])(nil)
+	_ sql.Scanner                      = (*Array[int64])(nil)
+	_ json.Marshaler                   = (*Array[int64])(nil)
+	_ json.Unmarshaler                 = (*Array[int64])(nil)
+	_ bson.ValueMarshaler              = (*Array[int64])(nil)
+	_ bson.ValueUnmarshaler            = (*Array[int64])(nil)
+	_ msgpack.Marshaler                = (*Array[int64])(nil)
+	_ msgpack.Unmarshaler              = (*Array[int64])(nil)
+	_ schema.QueryAppender             = (*Array[int64])(nil)
+	_ pgtype.ArraySetter               = (*Array[int64])(nil)
+	_ pgtype.ArrayGetter               = (*Array[int64])(nil)
+	_ gormschema.GormDataTypeInterface = (*Array[int64])(nil)
+	_ migrator.GormDataTypeInterface   = (*Array[int64])(nil)
+	_ gorm.Valuer                      = (*Array[int64])(nil)
+)
+
+// Scan implements sql.Scanner interface
+//
+// Both postgres array text, e.g. `{1,2,3}`, and JSON array, e.g. from MySQL `JSON` column, are accepted.
+// NULL element is rejected.
+func (d *Array[T]) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	if value == nil {
+		return nil
+	}
+
+	if data, ok := jsonArray(value); ok {
+		if err := json.Unmarshal(data, &d.Data); err != nil {
+			return err
+		}
+		d.Valid = true
+		return nil
+	}
+
+	var elems []arrayElement[T]
+	if err := (pg.GenericArray{A: &elems}).Scan(value); err != nil {
+		return err
+	}
+
+	d.Data = make([]T, len(elems))
+	for i, e := range elems {
+		d.Data[i] = e.Data
+	}
+	d.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (d Array[T]) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	if t, ok := any(d.data()).([]time.Time); ok {
+		// Quote timestamps, as the postgres format of pq has unquoted space
+		s := make([]string, len(t))
+		for i := range t {
+			s[i] = t[i].Format(time.RFC3339Nano)
+		}
+		return pg.GenericArray{A: s}.Value()
+	}
+	return pg.GenericArray{A: d.data()}.Value()
+}
+
+// AppendQuery implements bun/dialect.AppendQuery interface for PostgreSQL array support.
+func (d Array[T]) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	if !d.Valid {
+		return dialect.AppendNull(b), nil
+	}
+	return pgdialect.Array(d.data()).AppendQuery(gen, b)
+}
+
+// SetDimensions implements pgtype.ArraySetter interface.
+func (d *Array[T]) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	d.Present = true
+	if dimensions == nil {
+		d.Valid = false
+		d.Data = nil
+		return nil
+	}
+
+	d.Valid = true
+	d.Data = make([]T, arrayLength(dimensions))
+	return nil
+}
+
+// ScanIndex implements pgtype.ArraySetter interface.
+func (d *Array[T]) ScanIndex(i int) any {
+	return &d.Data[i]
+}
+
+// ScanIndexType implements pgtype.ArraySetter interface.
+func (d Array[T]) ScanIndexType() any {
+	return new(T)
+}
+
+// Dimensions implements pgtype.ArrayGetter interface.
+func (d Array[T]) Dimensions() []pgtype.ArrayDimension {
+	if !d.Valid {
+		return nil
+	}
+	return []pgtype.ArrayDimension{{Length: int32(len(d.Data)), LowerBound: 1}}
+}
+
+// Index implements pgtype.ArrayGetter interface.
+func (d Array[T]) Index(i int) any {
+	return d.Data[i]
+}
+
+// IndexType implements pgtype.ArrayGetter interface.
+func (d Array[T]) IndexType() any {
+	var zero T
+	return zero
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Array[T]) GormDataType() string {
+	return arrayElementType[T]() + "[]"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Array[T]) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return gormColumnType{Postgres: arrayElementType[T]() + "[]", MySQL: "JSON", SQLite: "JSON", SQLServer: "NVARCHAR(MAX)"}.of(db)
+}
+
+// GormValue implements gorm.Valuer interface.
+//
+// Array is written as postgres array, or as JSON array in other databases (casted to JSON in MySQL).
+func (d Array[T]) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if !d.Valid {
+		return gormNull
+	}
+	if db.Dialector.Name() == gormPostgres {
+		return gormValue(db, d)
+	}
+	data, err := json.Marshal(d.data())
+	return gormJSON(db, data, err)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d Array[T]) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte(`null`), nil
+	}
+	return json.Marshal(d.data())
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+func (d *Array[T]) UnmarshalJSON(data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &d.Data); err != nil {
+		return rejectError(data, reflect.TypeFor[[]T]().String(), err)
+	}
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// UUIDArray elements are encoded as binary subtype 4, like UUID.
+func (d Array[T]) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	if u, ok := any(d.data()).([]uuid.UUID); ok {
+		a := make(bson.A, len(u))
+		for i := range u {
+			a[i] = bson.Binary{Subtype: bson.TypeBinaryUUID, Data: u[i][:]}
+		}
+		t, byt, err := bson.MarshalValue(a)
+		return byte(t), byt, err
+	}
+	t, byt, err := bson.MarshalValue(d.data())
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Array[T]) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
+		return nil
+	}
+
+	if u, ok := any(&d.Data).(*[]uuid.UUID); ok {
+		var elems []UUID
+		if err := raw.Unmarshal(&elems); err != nil {
+			return rejectError(data, reflect.TypeFor[[]T]().String(), err)
+		}
+		*u = make([]uuid.UUID, len(elems))
+		for i, e := range elems {
+			if !e.Valid {
+				return rejectError(data, reflect.TypeFor[[]T]().String(), errors.New("nullable: null uuid array element"))
+			}
+			(*u)[i] = e.Data
+		}
+	} else if err := raw.Unmarshal(&d.Data); err != nil {
+		return rejectError(data, reflect.TypeFor[[]T]().String(), err)
+	}
+	d.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d Array[T]) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(d.data())
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *Array[T]) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val *[]T
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	d.Valid = true
+	d.Data = *val
+	return nil
+}
+
+func (Array[T]) FiberConverter(value string) reflect.Value {
+	var tmp []T
+	if err := json.Unmarshal([]byte(value), &tmp); err != nil {
+		return reflect.ValueOf(NewArray[T](nil, true, false))
+	}
+	return reflect.ValueOf(NewArray(tmp, true, true))
+}
+
+// data returns Data, or an empty slice if Data is nil, so a valid array is never encoded as null.
+func (d Array[T]) data() []T {
+	if d.Data == nil {
+		return []T{}
+	}
+	return d.Data
+}
+
+// arrayLength returns the number of elements of an array with dimensions.
+func arrayLength(dimensions []pgtype.ArrayDimension) int {
+	if len(dimensions) == 0 {
+		return 0
+	}
+	n := 1
+	for _, dim := range dimensions {
+		n *= int(dim.Length)
+	}
+	return n
+}
+
+// arrayElementType returns the postgres type of T.
+func arrayElementType[T ArrayElement]() string {
+	var zero T
+	switch any(zero).(type) {
+	case int64:
+		return "bigint"
+	case float64:
+		return "double precision"
+	case bool:
+		return "boolean"
+	case time.Time:
+		return "timestamptz"
+	case uuid.UUID:
+		return "uuid"
+	default:
+		return "text"
+	}
+}
+
+// jsonArray returns value as JSON array text, if value is a []byte or string that starts with `[`.
+func jsonArray(value interface{}) ([]byte, bool) {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, false
+	}
+	data = bytes.TrimSpace(data)
+	return data, len(data) > 0 && data[0] == '['
+}
+
+// arrayElement scans a postgres array element text into T.
+type arrayElement[T ArrayElement] struct {
+	Data T
+}
+
+// Scan implements sql.Scanner interface
+func (e *arrayElement[T]) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("nullable: null array element")
+	}
+
+	var err error
+	switch v := any(&e.Data).(type) {
+	case *string:
+		*v = string(b)
+	case *int64:
+		*v, err = strconv.ParseInt(string(b), 10, 64)
+	case *float64:
+		*v, err = strconv.ParseFloat(string(b), 64)
+	case *bool:
+		*v, err = strconv.ParseBool(string(b))
+	case *time.Time:
+		*v, err = pg.ParseTimestamp(nil, string(b))
+	case *uuid.UUID:
+		*v, err = uuid.ParseBytes(b)
+	default:
+		err = fmt.Errorf("nullable: unsupported array element %T", e.Data)
+	}
+	return err
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type intArrayJsonTest struct {
+	Value IntArray `json:"value,omitzero"`
+}
+
+func TestIntArray_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   intArrayJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   intArrayJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name:   "null value",
+			data:   intArrayJsonTest{Value: IntArray{Present: true}},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name:   "empty value",
+			data:   intArrayJsonTest{Value: NewArray[int64](nil)},
+			expect: bytes.NewBufferString(`{"value":[]}`),
+		},
+		{
+			name:   "valid value",
+			data:   intArrayJsonTest{Value: NewArray([]int64{1, 2, 3})},
+			expect: bytes.NewBufferString(`{"value":[1,2,3]}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestIntArray_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		buf    *bytes.Buffer
+		expect IntArray
+	}{
+		{
+			name:   "null value",
+			buf:    bytes.NewBufferString(`{"value":null}`),
+			expect: IntArray{Present: true},
+		},
+		{
+			name:   "empty value",
+			buf:    bytes.NewBufferString(`{"value":[]}`),
+			expect: NewArray([]int64{}),
+		},
+		{
+			name:   "valid value",
+			buf:    bytes.NewBufferString(`{"value":[1,2,3]}`),
+			expect: NewArray([]int64{1, 2, 3}),
+		},
+		{
+			name:   "missing value",
+			buf:    bytes.NewBufferString(`{}`),
+			expect: IntArray{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got intArrayJsonTest
+			if err := json.Unmarshal(tt.buf.Bytes(), &got); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got.Value, tt.expect) {
+				t.Errorf("expected value to be %+v got %+v", tt.expect, got.Value)
+			}
+		})
+	}
+}
+
+func TestArray_Scan(t *testing.T) {
+	var ints IntArray
+	if err := ints.Scan([]byte(`{1,2,3}`)); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !ints.Valid || !reflect.DeepEqual(ints.Data, []int64{1, 2, 3}) {
+		t.Errorf("expected value to be %v got %+v", []int64{1, 2, 3}, ints)
+	}
+
+	if err := ints.Scan(`{}`); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !ints.Valid || len(ints.Data) != 0 {
+		t.Errorf("expected empty valid value, got %+v", ints)
+	}
+
+	if err := ints.Scan([]byte(`[4,5]`)); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !ints.Valid || !reflect.DeepEqual(ints.Data, []int64{4, 5}) {
+		t.Errorf("expected value to be %v got %+v", []int64{4, 5}, ints)
+	}
+
+	if err := ints.Scan(`{1,NULL}`); err == nil {
+		t.Errorf("expected error scanning NULL element, got nil")
+	}
+
+	var bools BoolArray
+	if err := bools.Scan(`{t,f}`); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !reflect.DeepEqual(bools.Data, []bool{true, false}) {
+		t.Errorf("expected value to be %v got %v", []bool{true, false}, bools.Data)
+	}
+
+	var times TimeArray
+	if err := times.Scan(`{"2024-01-02 15:04:05+00"}`); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	expect := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	if len(times.Data) != 1 || !times.Data[0].Equal(expect) {
+		t.Errorf("expected value to be %v got %v", expect, times.Data)
+	}
+
+	var uuids UUIDArray
+	if err := uuids.Scan(`{` + testUUID.String() + `}`); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !reflect.DeepEqual(uuids.Data, []uuid.UUID{testUUID}) {
+		t.Errorf("expected value to be %v got %v", testUUID, uuids.Data)
+	}
+}
+
+func TestArray_Value(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   driver.Valuer
+		expect driver.Value
+	}{
+		{name: "null value", data: IntArray{Present: true}, expect: nil},
+		{name: "empty value", data: NewArray([]int64{}), expect: "{}"},
+		{name: "int value", data: NewArray([]int64{1, 2}), expect: "{1,2}"},
+		{name: "float value", data: NewArray([]float64{1.5}), expect: "{1.5}"},
+		{name: "uuid value", data: NewArray([]uuid.UUID{testUUID}), expect: `{"` + testUUID.String() + `"}`},
+		{
+			name:   "time value",
+			data:   NewArray([]time.Time{time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}),
+			expect: `{"2024-01-02T15:04:05Z"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.data.Value()
+			if err != nil {
+				t.Fatalf("unexpected value error: %s", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected value to be %v got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestArray_AppendQuery(t *testing.T) {
+	gen := schema.NewQueryGen(pgdialect.New())
+
+	b, err := NewArray([]int64{1, 2}).AppendQuery(gen, nil)
+	if err != nil {
+		t.Fatalf("unexpected append error: %s", err)
+	}
+	if string(b) != "'{1,2}'" {
+		t.Errorf("expected value to be %s got %s", "'{1,2}'", b)
+	}
+
+	b, err = IntArray{Present: true}.AppendQuery(gen, nil)
+	if err != nil {
+		t.Fatalf("unexpected append error: %s", err)
+	}
+	if string(b) != "NULL" {
+		t.Errorf("expected value to be NULL got %s", b)
+	}
+}
+
+type arrayEncodingTest struct {
+	Int  IntArray  `bson:"int,omitempty" msgpack:"int,omitempty"`
+	UUID UUIDArray `bson:"uuid,omitempty" msgpack:"uuid,omitempty"`
+	Time TimeArray `bson:"time,omitempty" msgpack:"time,omitempty"`
+}
+
+func TestArray_BSONMsgpack(t *testing.T) {
+	data := arrayEncodingTest{
+		Int:  NewArray([]int64{1, 2}),
+		UUID: NewArray([]uuid.UUID{testUUID}),
+		Time: NewArray([]time.Time{time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}),
+	}
+
+	byt, err := bson.Marshal(data)
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	var got arrayEncodingTest
+	if err = bson.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	got.Time.Data[0] = got.Time.Data[0].UTC()
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("expected value to be %+v got %+v", data, got)
+	}
+
+	if byt, err = msgpack.Marshal(data); err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	got = arrayEncodingTest{}
+	if err = msgpack.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	got.Time.Data[0] = got.Time.Data[0].UTC()
+	if !reflect.DeepEqual(got, data) {
+		t.Errorf("expected value to be %+v got %+v", data, got)
+	}
+}
@@ -14,9 +14,14 @@ import (
 	"encoding/json"
 	"reflect"
 
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.portalnesia.com/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 
 	"gopkg.in/guregu/null.v4"
 )
@@ -41,6 +46,12 @@ func (d Bool) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Bool) IsZero() bool {
+	return !d.Present
+}
+
 func NewBool(data bool, presentValid ...bool) Bool {
 	d := Bool{
 		Present: true,
@@ -73,14 +84,19 @@ func (d Bool) Ptr() *bool {
 }
 
 var (
-	_ driver.Valuer       = (*Bool)(nil)
-	_ sql.Scanner         = (*Bool)(nil)
-	_ json.Marshaler      = (*Bool)(nil)
-	_ json.Unmarshaler    = (*Bool)(nil)
-	_ bson.Marshaler      = (*Bool)(nil)
-	_ bson.Unmarshaler    = (*Bool)(nil)
-	_ msgpack.Marshaler   = (*Bool)(nil)
-	_ msgpack.Unmarshaler = (*Bool)(nil)
+	_ driver.Valuer                    = (*Bool)(nil)
+	_ sql.Scanner                      = (*Bool)(nil)
+	_ json.Marshaler                   = (*Bool)(nil)
+	_ json.Unmarshaler                 = (*Bool)(nil)
+	_ bson.ValueMarshaler              = (*Bool)(nil)
+	_ bson.ValueUnmarshaler            = (*Bool)(nil)
+	_ msgpack.Marshaler                = (*Bool)(nil)
+	_ msgpack.Unmarshaler              = (*Bool)(nil)
+	_ pgtype.BoolScanner               = (*Bool)(nil)
+	_ pgtype.BoolValuer                = (*Bool)(nil)
+	_ schema.QueryAppender             = (*Bool)(nil)
+	_ gormschema.GormDataTypeInterface = (*Bool)(nil)
+	_ migrator.GormDataTypeInterface   = (*Bool)(nil)
 )
 
 // Scan implements sql.Scanner interface
@@ -104,6 +120,34 @@ func (d Bool) Value() (driver.Value, error) {
 	return d.Data, nil
 }
 
+// ScanBool implements pgtype.BoolScanner interface.
+func (d *Bool) ScanBool(v pgtype.Bool) error {
+	d.Present = true
+	d.Valid = v.Valid
+	d.Data = v.Bool
+	return nil
+}
+
+// BoolValue implements pgtype.BoolValuer interface.
+func (d Bool) BoolValue() (pgtype.Bool, error) {
+	return pgtype.Bool{Bool: d.Data, Valid: d.Valid}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Bool) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Bool) GormDataType() string {
+	return string(gormschema.Bool)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Bool) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return db.Dialector.DataTypeOf(field)
+}
+
 // MarshalJSON implements json.Marshaler interface.
 func (d Bool) MarshalJSON() ([]byte, error) {
 	if !d.Present {
@@ -123,36 +167,34 @@ func (d *Bool) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &d.Data); err != nil {
-		return nil
+		return decodeError(data, "bool", err)
 	}
 
 	d.Valid = true
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d Bool) MarshalBSON() (byt []byte, err error) {
-	var tmp *bool
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d Bool) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
 	}
-	_, byt, err = bson.MarshalValue(d.Data)
-	return byt, err
+	t, byt, err := bson.MarshalValue(d.Data)
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *Bool) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Bool) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
 		return nil
 	}
 
-	if err := bson.Unmarshal(data, &d.Data); err != nil {
-		return nil
+	if err := raw.Unmarshal(&d.Data); err != nil {
+		return decodeError(data, "bool", err)
 	}
 
 	d.Valid = true
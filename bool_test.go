@@ -15,7 +15,7 @@ import (
 )
 
 type boolJsonTest struct {
-	Value Bool `json:"value"`
+	Value Bool `json:"value,omitzero"`
 }
 
 func TestBool_MarshalJSON(t *testing.T) {
@@ -24,6 +24,11 @@ func TestBool_MarshalJSON(t *testing.T) {
 		data   boolJsonTest
 		expect *bytes.Buffer
 	}{
+		{
+			name:   "undefined value",
+			data:   boolJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
 		{
 			name: "null value",
 			data: boolJsonTest{
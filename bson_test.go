@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"reflect"
+	"testing"
+
+	pg "github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type bsonTest struct {
+	String      String            `bson:"string,omitempty"`
+	Int         Int               `bson:"int,omitempty"`
+	Float       Float             `bson:"float,omitempty"`
+	Bool        Bool              `bson:"bool,omitempty"`
+	StringArray StringArray       `bson:"string_array,omitempty"`
+	Type        Type[nestedValue] `bson:"type,omitempty"`
+}
+
+func TestBSON_Marshal(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   bsonTest
+		expect bson.D
+	}{
+		{
+			name:   "undefined value",
+			data:   bsonTest{},
+			expect: bson.D{},
+		},
+		{
+			name: "null value",
+			data: bsonTest{
+				String:      String{Present: true},
+				Int:         Int{Present: true},
+				Float:       Float{Present: true},
+				Bool:        Bool{Present: true},
+				StringArray: StringArray{Present: true},
+				Type:        Type[nestedValue]{Present: true},
+			},
+			expect: bson.D{
+				{Key: "string", Value: nil},
+				{Key: "int", Value: nil},
+				{Key: "float", Value: nil},
+				{Key: "bool", Value: nil},
+				{Key: "string_array", Value: nil},
+				{Key: "type", Value: nil},
+			},
+		},
+		{
+			name: "valid value",
+			data: bsonTest{
+				String:      NewString("string"),
+				Int:         NewInt(5),
+				Float:       NewFloat(1.5),
+				Bool:        NewBool(false),
+				StringArray: NewStringArray(pg.StringArray{"a", "b"}),
+				Type:        NewType(nestedValue{Nested: "nested"}),
+			},
+			expect: bson.D{
+				{Key: "string", Value: "string"},
+				{Key: "int", Value: int64(5)},
+				{Key: "float", Value: 1.5},
+				{Key: "bool", Value: false},
+				{Key: "string_array", Value: bson.A{"a", "b"}},
+				{Key: "type", Value: bson.D{{Key: "nested", Value: "nested"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byt, err := bson.Marshal(tt.data)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+			expect, err := bson.Marshal(tt.expect)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+			if !reflect.DeepEqual(byt, expect) {
+				t.Errorf("expected value to be %s got %s", bson.Raw(expect), bson.Raw(byt))
+			}
+
+			var got bsonTest
+			if err := bson.Unmarshal(byt, &got); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.data) {
+				t.Errorf("expected value to be %#v got %#v", tt.data, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// appendQueryValue appends the driver.Value of v to b, or NULL if it is nil.
+func appendQueryValue(gen schema.QueryGen, b []byte, v driver.Valuer) ([]byte, error) {
+	val, err := v.Value()
+	if err != nil {
+		return nil, err
+	}
+	return gen.Append(b, val), nil
+}
+
+// BunColumns returns the columns of present fields of v, a struct (or pointer to struct) of nullable fields,
+// for bun.UpdateQuery Column, so only present fields are updated.
+//
+// The column name is taken from `bun` tag, or the snake_case of the field name.
+// Fields tagged with `bun:"-"` are skipped.
+//
+// bun updates all columns when Column is not called with any column,
+// so check that the result is not empty before querying:
+//
+//	columns, err := nullable.BunColumns(&user)
+//	if err != nil || len(columns) == 0 {
+//	    return err
+//	}
+//	db.NewUpdate().Model(&user).Column(columns...).WherePK().Exec(ctx)
+func BunColumns(v any) ([]string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("nullable: update value must be a struct")
+	}
+
+	var columns []string
+	for _, f := range nullableFields(rv, "bun") {
+		if !f.Nullable.IsPresent() {
+			continue
+		}
+		column := f.Tag
+		if column == "" {
+			column = toSnakeCase(f.Name)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"database/sql"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/schema"
+)
+
+func TestAppendQuery(t *testing.T) {
+	gen := schema.NewQueryGen(pgdialect.New())
+
+	tests := []struct {
+		name   string
+		data   schema.QueryAppender
+		expect string
+	}{
+		{name: "null string", data: String{Present: true}, expect: `NULL`},
+		{name: "empty string", data: NewString(""), expect: `''`},
+		{name: "string", data: NewString("it's"), expect: `'it''s'`},
+		{name: "int", data: NewInt(42), expect: `42`},
+		{name: "float", data: NewFloat(1.5), expect: `1.5`},
+		{name: "bool", data: NewBool(true), expect: `TRUE`},
+		{name: "time", data: NewTime(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)), expect: `'2024-01-02 15:04:05+00:00'`},
+		{name: "date", data: NewDate(time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)), expect: `'2024-01-02'`},
+		{name: "decimal", data: NewDecimal(big.NewRat(5, 2)), expect: `'2.5'`},
+		{name: "uuid", data: NewUUID(testUUID), expect: `'` + testUUID.String() + `'`},
+		{name: "number", data: NewNumber[int16](7), expect: `7`},
+		{name: "null type", data: Type[testValue]{Present: true}, expect: `NULL`},
+		{name: "type", data: NewType(map[string]string{"name": "it's"}), expect: `'{"name":"it''s"}'::jsonb`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.data.AppendQuery(gen, nil)
+			if err != nil {
+				t.Fatalf("unexpected append error: %s", err)
+			}
+			if string(b) != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, b)
+			}
+		})
+	}
+}
+
+type bunTestModel struct {
+	bun.BaseModel `bun:"table:users"`
+
+	ID       int64 `bun:"id,pk"`
+	Name     String
+	Age      Int
+	Tags     StringArray
+	Nickname String `bun:"nick"`
+	Ignored  String `bun:"-"`
+}
+
+func TestBunColumns(t *testing.T) {
+	model := &bunTestModel{
+		ID:       1,
+		Name:     NewString("john"),
+		Age:      Int{Present: true},
+		Nickname: NewString("jo"),
+		Ignored:  NewString("ignored"),
+	}
+
+	columns, err := BunColumns(model)
+	if err != nil {
+		t.Fatalf("unexpected columns error: %s", err)
+	}
+	expect := []string{"name", "age", "nick"}
+	if !reflect.DeepEqual(columns, expect) {
+		t.Errorf("expected value to be %v got %v", expect, columns)
+	}
+
+	sqldb, err := sql.Open("postgres", "")
+	if err != nil {
+		t.Fatalf("unexpected open error: %s", err)
+	}
+	db := bun.NewDB(sqldb, pgdialect.New())
+	defer db.Close()
+
+	query := db.NewUpdate().Model(model).Column(columns...).WherePK().String()
+	expectQuery := `UPDATE "users" AS "bun_test_model" SET "name" = 'john', "age" = NULL, "nick" = 'jo' WHERE ("bun_test_model"."id" = 1)`
+	if query != expectQuery {
+		t.Errorf("expected query to be %s got %s", expectQuery, query)
+	}
+
+	if _, err = BunColumns("name"); err == nil {
+		t.Errorf("expected error for non struct value, got nil")
+	}
+}
@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// DateLayout is the layout used to format and parse Date.
+const DateLayout = time.DateOnly
+
+// Date represents a calendar date (year, month, day) without time and timezone
+// that may be null or not present in JSON at all.
+//
+// Data is always at midnight UTC, so the date never shifts between timezones.
+type Date struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid date
+	Data    time.Time
+}
+
+// NewDate creates Date from the year, month and day of data in its own location.
+func NewDate(data time.Time, presentValid ...bool) Date {
+	d := Date{
+		Present: true,
+		Valid:   true,
+		Data:    dateOf(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+
+	return d
+}
+
+func NewDatePtr(data time.Time, presentValid ...bool) *Date {
+	d := NewDate(data, presentValid...)
+	return &d
+}
+
+// ParseDate parses `YYYY-MM-DD` string s into a valid Date.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return NewDate(t), nil
+}
+
+func (d Date) IsPresent() bool {
+	return d.Present
+}
+
+func (d Date) IsValid() bool {
+	return d.Valid
+}
+
+func (d Date) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Date) IsZero() bool {
+	return !d.Present
+}
+
+func (d Date) Null() null.Time {
+	return null.NewTime(d.Data, d.Present && d.Valid)
+}
+
+func (d Date) Ptr() *time.Time {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+// String returns the date in `YYYY-MM-DD` format, or empty string if it is not valid.
+func (d Date) String() string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Data.Format(DateLayout)
+}
+
+var (
+	_ driver.Valuer                    = (*Date)(nil)
+	_ sql.Scanner                      = (*Date)(nil)
+	_ json.Marshaler                   = (*Date)(nil)
+	_ json.Unmarshaler                 = (*Date)(nil)
+	_ bson.ValueMarshaler              = (*Date)(nil)
+	_ bson.ValueUnmarshaler            = (*Date)(nil)
+	_ msgpack.Marshaler                = (*Date)(nil)
+	_ msgpack.Unmarshaler              = (*Date)(nil)
+	_ pgtype.DateScanner               = (*Date)(nil)
+	_ pgtype.DateValuer                = (*Date)(nil)
+	_ schema.QueryAppender             = (*Date)(nil)
+	_ gormschema.GormDataTypeInterface = (*Date)(nil)
+	_ migrator.GormDataTypeInterface   = (*Date)(nil)
+)
+
+// Scan implements sql.Scanner interface
+func (d *Date) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		t   time.Time
+		err error
+	)
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		t = v
+	case []byte:
+		t, err = parseDateColumn(string(v))
+	case string:
+		t, err = parseDateColumn(v)
+	default:
+		return errors.New(fmt.Sprint("Failed to scan date value:", value))
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Valid = true
+	d.Data = dateOf(t)
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (d Date) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Data.Format(DateLayout), nil
+}
+
+// ScanDate implements pgtype.DateScanner interface.
+//
+// Infinite date is rejected, as it has no time.Time representation.
+func (d *Date) ScanDate(v pgtype.Date) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("nullable: cannot scan %s date", v.InfinityModifier)
+	}
+	d.Valid = true
+	d.Data = dateOf(v.Time)
+	return nil
+}
+
+// DateValue implements pgtype.DateValuer interface.
+func (d Date) DateValue() (pgtype.Date, error) {
+	return pgtype.Date{Time: d.Data, Valid: d.Valid}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Date) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Date) GormDataType() string {
+	return "date"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Date) GormDBDataType(*gorm.DB, *gormschema.Field) string {
+	return "date"
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Data.Format(DateLayout))
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var dateString string
+	if err := json.Unmarshal(data, &dateString); err != nil {
+		return rejectError(data, "date", err)
+	}
+
+	t, err := time.Parse(DateLayout, dateString)
+	if err != nil {
+		return rejectError(data, "date", err)
+	}
+	d.Data = t
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// Date is encoded as BSON DateTime at midnight UTC.
+func (d Date) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	t, byt, err := bson.MarshalValue(bson.NewDateTimeFromTime(d.Data))
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Date) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		raw = bson.RawValue{Type: bson.Type(t), Value: data}
+		tm  time.Time
+		err error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil
+	case bson.TypeDateTime:
+		tm = raw.Time().UTC()
+	case bson.TypeString:
+		tm, err = parseDateColumn(raw.StringValue())
+	default:
+		err = fmt.Errorf("nullable: unsupported bson type %s for date", raw.Type)
+	}
+	if err != nil {
+		return rejectError(data, "date", err)
+	}
+
+	d.Data = dateOf(tm)
+	d.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d Date) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(d.Data.Format(DateLayout))
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *Date) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val interface{}
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	return d.Scan(val)
+}
+
+func (Date) FiberConverter(value string) reflect.Value {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		a := NewDate(t, true, false)
+		return reflect.ValueOf(a)
+	}
+	a := NewDate(t, true, true)
+	return reflect.ValueOf(a)
+}
+
+// dateOf returns the date of t in its own location, at midnight UTC.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// parseDateColumn parses the date part of a date or timestamp text, e.g. `2024-01-02 15:04:05`.
+func parseDateColumn(s string) (time.Time, error) {
+	if len(s) > len(DateLayout) {
+		s = s[:len(DateLayout)]
+	}
+	return time.Parse(DateLayout, s)
+}
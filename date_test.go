@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"encoding/json"
+)
+
+type dateJsonTest struct {
+	Value Date `json:"value,omitzero"`
+}
+
+func TestDate_MarshalJSON(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	tests := []struct {
+		name   string
+		data   dateJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   dateJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: dateJsonTest{
+				Value: Date{
+					Present: true,
+					Valid:   false,
+				},
+			},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name: "valid value",
+			data: dateJsonTest{
+				Value: NewDate(time.Date(2024, 1, 2, 3, 0, 0, 0, jakarta)),
+			},
+			expect: bytes.NewBufferString(`{"value":"2024-01-02"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		buf    *bytes.Buffer
+		expect Date
+	}{
+		{
+			name: "null value",
+			buf:  bytes.NewBufferString(`{"value":null}`),
+			expect: Date{
+				Present: true,
+			},
+		},
+		{
+			name:   "valid value",
+			buf:    bytes.NewBufferString(`{"value":"2024-01-02"}`),
+			expect: NewDate(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:   "empty",
+			buf:    bytes.NewBufferString(`null`),
+			expect: Date{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := struct {
+				Value Date `json:"value"`
+			}{}
+
+			if err := json.Unmarshal(tt.buf.Bytes(), &str); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			got := str.Value
+			if got.Present != tt.expect.Present || got.Valid != tt.expect.Valid || !got.Data.Equal(tt.expect.Data) {
+				t.Errorf("expected value to be %#v got %#v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestDate_Scan(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	for _, value := range []interface{}{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, jakarta),
+		"2024-01-02",
+		[]byte("2024-01-02 00:00:00"),
+	} {
+		var got Date
+		if err := got.Scan(value); err != nil {
+			t.Fatalf("unexpected scan error: %s", err)
+		}
+		if got.String() != "2024-01-02" {
+			t.Errorf("expected value to be 2024-01-02 got %s", got.String())
+		}
+	}
+}
@@ -0,0 +1,426 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+)
+
+var (
+	// DecimalMarshalJSONAsString controls whether Decimal is marshaled to JSON as a string (e.g. "12.5")
+	// or as a number (e.g. 12.5). String is the default, as most JSON parsers decode numbers into float64.
+	//
+	// It should be set once, before any marshaling takes place.
+	DecimalMarshalJSONAsString = true
+
+	// DecimalPrecision is the number of digits after the decimal point
+	// used to format a Decimal that has no exact decimal representation (e.g. 1/3).
+	DecimalPrecision = 16
+
+	// DecimalMaxDigits is the maximum number of digits of a parsed Decimal,
+	// including the zeros implied by the exponent (e.g. 1e5 has 6 digits).
+	// Larger input is rejected, as `1e999999` would allocate a million digits.
+	//
+	// It should be set once, before any decoding takes place.
+	DecimalMaxDigits = 1000
+)
+
+// Decimal represents an arbitrary-precision decimal that may be null or not
+// present in JSON at all.
+type Decimal struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid decimal
+	Data    *big.Rat
+}
+
+func NewDecimal(data *big.Rat, presentValid ...bool) Decimal {
+	d := Decimal{
+		Present: true,
+		Valid:   data != nil,
+		Data:    data,
+	}
+
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewDecimalPtr(data *big.Rat, presentValid ...bool) *Decimal {
+	d := NewDecimal(data, presentValid...)
+	return &d
+}
+
+// ParseDecimal parses decimal string, e.g. "1234.5678", into a valid Decimal.
+func ParseDecimal(s string) (Decimal, error) {
+	r, err := parseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewDecimal(r), nil
+}
+
+func (d Decimal) IsPresent() bool {
+	return d.Present
+}
+
+func (d Decimal) IsValid() bool {
+	return d.Valid
+}
+
+func (d Decimal) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Decimal) IsZero() bool {
+	return !d.Present
+}
+
+func (d Decimal) Ptr() *big.Rat {
+	if d.Valid {
+		return d.Data
+	}
+	return nil
+}
+
+// String returns the decimal representation of the value, or empty string if it is not valid.
+func (d Decimal) String() string {
+	if !d.Valid || d.Data == nil {
+		return ""
+	}
+	return decimalString(d.Data)
+}
+
+var (
+	_ driver.Valuer                    = (*Decimal)(nil)
+	_ sql.Scanner                      = (*Decimal)(nil)
+	_ json.Marshaler                   = (*Decimal)(nil)
+	_ json.Unmarshaler                 = (*Decimal)(nil)
+	_ bson.ValueMarshaler              = (*Decimal)(nil)
+	_ bson.ValueUnmarshaler            = (*Decimal)(nil)
+	_ msgpack.Marshaler                = (*Decimal)(nil)
+	_ msgpack.Unmarshaler              = (*Decimal)(nil)
+	_ pgtype.NumericScanner            = (*Decimal)(nil)
+	_ pgtype.NumericValuer             = (*Decimal)(nil)
+	_ schema.QueryAppender             = (*Decimal)(nil)
+	_ gormschema.GormDataTypeInterface = (*Decimal)(nil)
+	_ migrator.GormDataTypeInterface   = (*Decimal)(nil)
+)
+
+// Scan implements sql.Scanner interface
+func (d *Decimal) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	if value == nil {
+		return nil
+	}
+
+	r, err := toDecimal(value)
+	if err != nil {
+		return err
+	}
+	d.Valid = true
+	d.Data = r
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid || d.Data == nil {
+		return nil, nil
+	}
+	return decimalString(d.Data), nil
+}
+
+// ScanNumeric implements pgtype.NumericScanner interface.
+//
+// NaN and infinity are rejected, as they have no big.Rat representation.
+func (d *Decimal) ScanNumeric(v pgtype.Numeric) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	if v.NaN {
+		return errors.New("nullable: cannot scan NaN decimal")
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("nullable: cannot scan %s decimal", v.InfinityModifier)
+	}
+
+	s, err := v.Value()
+	if err != nil {
+		return err
+	}
+	return d.Scan(s)
+}
+
+// NumericValue implements pgtype.NumericValuer interface.
+func (d Decimal) NumericValue() (pgtype.Numeric, error) {
+	var n pgtype.Numeric
+	if !d.Valid || d.Data == nil {
+		return n, nil
+	}
+	err := n.Scan(decimalString(d.Data))
+	return n, err
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Decimal) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Decimal) GormDataType() string {
+	return "decimal"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Decimal) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	if field.Precision > 0 {
+		return fmt.Sprintf("decimal(%d,%d)", field.Precision, field.Scale)
+	}
+	return gormColumnType{Postgres: "numeric", MySQL: "decimal(65,30)", SQLite: "numeric", SQLServer: "decimal(38,18)"}.of(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid || d.Data == nil {
+		return []byte("null"), nil
+	}
+	if DecimalMarshalJSONAsString {
+		return json.Marshal(decimalString(d.Data))
+	}
+	return []byte(decimalString(d.Data)), nil
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both JSON number and JSON string are accepted.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return decodeError(data, "decimal", err)
+		}
+	}
+
+	r, err := parseDecimal(s)
+	if err != nil {
+		return decodeError(data, "decimal", err)
+	}
+
+	d.Data = r
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d Decimal) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid || d.Data == nil {
+		return byte(bson.TypeNull), nil, nil
+	}
+	dec, err := bson.ParseDecimal128(decimalString(d.Data))
+	if err != nil {
+		return 0, nil, err
+	}
+	t, byt, err := bson.MarshalValue(dec)
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Decimal) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		raw = bson.RawValue{Type: bson.Type(t), Value: data}
+		r   *big.Rat
+		err error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil
+	case bson.TypeDecimal128:
+		r, err = parseDecimal(raw.Decimal128().String())
+	case bson.TypeString:
+		r, err = parseDecimal(raw.StringValue())
+	case bson.TypeInt32, bson.TypeInt64:
+		r = new(big.Rat).SetInt64(raw.AsInt64())
+	case bson.TypeDouble:
+		r, err = toDecimal(raw.Double())
+	default:
+		err = fmt.Errorf("unsupported bson type %s", raw.Type)
+	}
+	if err != nil {
+		return decodeError(data, "decimal", err)
+	}
+
+	d.Data = r
+	d.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+//
+// Decimal is encoded as string to keep its precision.
+func (d Decimal) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid || d.Data == nil {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(decimalString(d.Data))
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *Decimal) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val interface{}
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	r, err := toDecimal(val)
+	if err != nil {
+		return err
+	}
+	d.Valid = true
+	d.Data = r
+	return nil
+}
+
+func (Decimal) FiberConverter(value string) reflect.Value {
+	r, err := parseDecimal(value)
+	if err != nil {
+		a := NewDecimal(nil, true, false)
+		return reflect.ValueOf(a)
+	}
+	a := NewDecimal(r, true, true)
+	return reflect.ValueOf(a)
+}
+
+// parseDecimal parses decimal string s, e.g. `-12.5` or `1.25e-3`.
+// Fraction (e.g. 1/3), other bases and more than DecimalMaxDigits digits are not accepted.
+func parseDecimal(s string) (*big.Rat, error) {
+	n, ok := decimalDigits(s)
+	if !ok {
+		return nil, fmt.Errorf("nullable: invalid decimal %q", s)
+	}
+	if n > DecimalMaxDigits {
+		return nil, fmt.Errorf("nullable: decimal has %d digits, more than %d", n, DecimalMaxDigits)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("nullable: invalid decimal %q", s)
+	}
+	return r, nil
+}
+
+// decimalDigits returns the number of digits of decimal string s, including the zeros implied by the exponent.
+// ok is false if s is not in decimal notation.
+func decimalDigits(s string) (n int, ok bool) {
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	mantissa, exp, hasExp := strings.Cut(strings.ToLower(s), "e")
+	for _, c := range mantissa {
+		if c == '.' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	if hasExp {
+		e, err := strconv.Atoi(exp)
+		if err != nil {
+			return 0, false
+		}
+		if e < 0 {
+			e = -e
+		}
+		n += e
+	}
+	return n, true
+}
+
+// toDecimal converts integer, float, string or []byte value into *big.Rat.
+func toDecimal(value interface{}) (*big.Rat, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetUint64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		// Use the shortest representation, so 0.1 does not become 0.1000000000000000055511151231257827
+		return parseDecimal(strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()))
+	case reflect.String:
+		return parseDecimal(rv.String())
+	case reflect.Slice:
+		if b, ok := value.([]byte); ok {
+			return parseDecimal(string(b))
+		}
+	}
+	return nil, errors.New(fmt.Sprint("Failed to scan decimal value:", value))
+}
+
+// decimalString formats r with as many digits after the decimal point as needed,
+// or DecimalPrecision digits if r has no exact decimal representation.
+func decimalString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	if n, exact := r.FloatPrec(); exact {
+		return r.FloatString(n)
+	}
+	return r.FloatString(DecimalPrecision)
+}
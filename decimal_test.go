@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type decimalJsonTest struct {
+	Value Decimal `json:"value,omitzero" bson:"value,omitempty"`
+}
+
+func mustDecimal(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := ParseDecimal(s)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err)
+	}
+	return d
+}
+
+func TestDecimal_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     decimalJsonTest
+		asNumber bool
+		expect   *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   decimalJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: decimalJsonTest{
+				Value: Decimal{
+					Present: true,
+					Valid:   false,
+				},
+			},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name: "valid value",
+			data: decimalJsonTest{
+				Value: mustDecimal(t, "12345678901234567.0123"),
+			},
+			expect: bytes.NewBufferString(`{"value":"12345678901234567.0123"}`),
+		},
+		{
+			name: "valid number",
+			data: decimalJsonTest{
+				Value: mustDecimal(t, "0.1"),
+			},
+			asNumber: true,
+			expect:   bytes.NewBufferString(`{"value":0.1}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			DecimalMarshalJSONAsString = !tt.asNumber
+			defer func() { DecimalMarshalJSONAsString = true }()
+
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		buf    *bytes.Buffer
+		expect string
+		valid  bool
+	}{
+		{
+			name: "null value",
+			buf:  bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name:   "string value",
+			buf:    bytes.NewBufferString(`{"value":"12345678901234567.0123"}`),
+			expect: "12345678901234567.0123",
+			valid:  true,
+		},
+		{
+			name:   "number value",
+			buf:    bytes.NewBufferString(`{"value":0.1}`),
+			expect: "0.1",
+			valid:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := struct {
+				Value Decimal `json:"value"`
+			}{}
+
+			if err := json.Unmarshal(tt.buf.Bytes(), &str); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			got := str.Value
+			if !got.Present || got.Valid != tt.valid || got.String() != tt.expect {
+				t.Errorf("expected value to be %s got %#v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestDecimal_BSON(t *testing.T) {
+	data := decimalJsonTest{Value: NewDecimal(big.NewRat(12345, 100))}
+
+	byt, err := bson.Marshal(data)
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	if typ := bson.Raw(byt).Lookup("value").Type; typ != bson.TypeDecimal128 {
+		t.Fatalf("expected bson type to be decimal128 got %s", typ)
+	}
+
+	var got decimalJsonTest
+	if err := bson.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if got.Value.String() != "123.45" {
+		t.Errorf("expected value to be 123.45 got %s", got.Value.String())
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		expect string
+		err    bool
+	}{
+		{name: "decimal", value: "-12.50", expect: "-12.5"},
+		{name: "exponent", value: "1.25e-3", expect: "0.00125"},
+		{name: "positive exponent", value: "+1E+3", expect: "1000"},
+		{name: "max digits", value: "1e999", expect: "1" + strings.Repeat("0", 999)},
+		{name: "exponent beyond max digits", value: "1e999999", err: true},
+		{name: "negative exponent beyond max digits", value: "1e-999999", err: true},
+		{name: "digits beyond max digits", value: strings.Repeat("9", 1001), err: true},
+		{name: "exponent overflow", value: "1e99999999999999999999", err: true},
+		{name: "fraction", value: "1/3", err: true},
+		{name: "hexadecimal", value: "0x1p999999", err: true},
+		{name: "empty", value: "", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDecimal(tt.value)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected error parsing %.20s, got %s", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected parsing error: %s", err)
+			}
+			if got.String() != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, got)
+			}
+		})
+	}
+}
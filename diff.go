@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// JSON Patch (RFC 6902) operations generated by Diff.
+const (
+	OpAdd     = "add"
+	OpReplace = "replace"
+	OpRemove  = "remove"
+)
+
+// PatchOperation is a single JSON Patch (RFC 6902) operation.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// Value is always written for `add` and `replace`, even if it is null or zero,
+// and omitted for `remove`, which has no value.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == OpRemove {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	type operation PatchOperation
+	return json.Marshal(operation(o))
+}
+
+// Diff compares two structs (or pointers to struct) of nullable fields and returns
+// JSON Patch (RFC 6902) operations that transform from into to.
+//
+// Fields are matched by json tag name, or the struct field name if the tag is not set.
+// Fields that are not present in to are considered unchanged.
+//
+//   - Valid in to but not in from produces `add`.
+//   - Valid in both with different value produces `replace`.
+//   - Present and not valid in to produces `replace` with null value if it is valid in from,
+//     or `add` with null value if it is not present in from.
+//
+// When both values of Type[D] hold a struct with nullable fields, it is compared recursively.
+func Diff(from, to any) ([]PatchOperation, error) {
+	fv := reflect.Indirect(reflect.ValueOf(from))
+	tv := reflect.Indirect(reflect.ValueOf(to))
+	if fv.Kind() != reflect.Struct || tv.Kind() != reflect.Struct {
+		return nil, errors.New("nullable: diff values must be struct")
+	}
+	return diff(fv, tv, ""), nil
+}
+
+func diff(from, to reflect.Value, prefix string) []PatchOperation {
+	fromFields := make(map[string]nullableField)
+	for _, f := range nullableFields(from, "json") {
+		fromFields[f.Key()] = f
+	}
+
+	var ops []PatchOperation
+	for _, t := range nullableFields(to, "json") {
+		if !t.Nullable.IsPresent() {
+			continue
+		}
+		path := prefix + "/" + escapePointer(t.Key())
+		f, ok := fromFields[t.Key()]
+		fromPresent := ok && f.Nullable.IsPresent()
+		fromValid := fromPresent && f.Nullable.IsValid()
+
+		if !t.Nullable.IsValid() {
+			// null is a value in JSON, so a null field is replaced rather than removed
+			if fromValid {
+				ops = append(ops, PatchOperation{Op: OpReplace, Path: path, Value: nil})
+			} else if !fromPresent {
+				ops = append(ops, PatchOperation{Op: OpAdd, Path: path, Value: nil})
+			}
+			continue
+		}
+		if !fromValid {
+			ops = append(ops, PatchOperation{Op: OpAdd, Path: path, Value: t.Nullable.GetValue()})
+			continue
+		}
+
+		fromValue := reflect.ValueOf(f.Nullable.GetValue())
+		toValue := reflect.ValueOf(t.Nullable.GetValue())
+		if fromValue.Kind() == reflect.Struct && toValue.Kind() == reflect.Struct && hasNullableFields(toValue.Type()) {
+			ops = append(ops, diff(fromValue, toValue, path)...)
+			continue
+		}
+		if !reflect.DeepEqual(fromValue.Interface(), toValue.Interface()) {
+			ops = append(ops, PatchOperation{Op: OpReplace, Path: path, Value: toValue.Interface()})
+		}
+	}
+	return ops
+}
+
+// escapePointer escapes JSON Pointer (RFC 6901) reference token.
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
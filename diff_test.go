@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type diffAddress struct {
+	City    String `json:"city"`
+	Country String `json:"country"`
+}
+
+type diffTest struct {
+	Name    String            `json:"name"`
+	Age     Int               `json:"age"`
+	Email   String            `json:"email"`
+	Path    String            `json:"a/b"`
+	Address Type[diffAddress] `json:"address"`
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   diffTest
+		to     diffTest
+		expect string
+	}{
+		{
+			name:   "undefined",
+			from:   diffTest{Name: NewString("name")},
+			to:     diffTest{},
+			expect: `null`,
+		},
+		{
+			name: "add replace null",
+			from: diffTest{
+				Name:  NewString("name"),
+				Age:   NewInt(20),
+				Email: NewString("mail"),
+			},
+			to: diffTest{
+				Name:  NewString("name"),
+				Age:   NewInt(21),
+				Email: NewString("", true, false),
+				Path:  NewString("path"),
+			},
+			expect: `[{"op":"replace","path":"/age","value":21},{"op":"replace","path":"/email","value":null},{"op":"add","path":"/a~1b","value":"path"}]`,
+		},
+		{
+			name: "zero and null value",
+			from: diffTest{
+				Name: NewString("name"),
+				Age:  NewInt(20),
+			},
+			to: diffTest{
+				Name:  NewString(""),
+				Age:   NewInt(0),
+				Email: NewString("", true, false),
+				Path:  NewString("", true, false),
+			},
+			expect: `[{"op":"replace","path":"/name","value":""},{"op":"replace","path":"/age","value":0},{"op":"add","path":"/email","value":null},{"op":"add","path":"/a~1b","value":null}]`,
+		},
+		{
+			name: "nested value",
+			from: diffTest{
+				Address: NewType(diffAddress{City: NewString("Jakarta"), Country: NewString("Indonesia")}),
+			},
+			to: diffTest{
+				Address: NewType(diffAddress{City: NewString("Denpasar")}),
+			},
+			expect: `[{"op":"replace","path":"/address/city","value":"Denpasar"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Diff(tt.from, &tt.to)
+			if err != nil {
+				t.Fatalf("unexpected diff error: %s", err)
+			}
+
+			byt, err := json.Marshal(ops)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+			if string(byt) != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestPatchOperation_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		op     PatchOperation
+		expect string
+	}{
+		{
+			name:   "add null",
+			op:     PatchOperation{Op: OpAdd, Path: "/name"},
+			expect: `{"op":"add","path":"/name","value":null}`,
+		},
+		{
+			name:   "replace zero",
+			op:     PatchOperation{Op: OpReplace, Path: "/age", Value: 0},
+			expect: `{"op":"replace","path":"/age","value":0}`,
+		},
+		{
+			name:   "remove",
+			op:     PatchOperation{Op: OpRemove, Path: "/name"},
+			expect: `{"op":"remove","path":"/name"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byt, err := json.Marshal(tt.op)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+			if string(byt) != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
@@ -0,0 +1,585 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+)
+
+// Duration lengths used to convert calendar units of ISO 8601 and postgres interval,
+// as time.Duration can not represent them exactly.
+const (
+	durationDay   = 24 * time.Hour
+	durationWeek  = 7 * durationDay
+	durationMonth = 30 * durationDay
+	durationYear  = 365 * durationDay
+)
+
+// Duration represents a time.Duration that may be null or not
+// present in JSON at all.
+//
+// It accepts Go syntax (e.g. `1h30m`), ISO 8601 (e.g. `PT1H30M`) and postgres interval (e.g. `1 day 01:30:00`).
+// Month and year are converted as 30 days and 365 days.
+type Duration struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid duration
+	Data    time.Duration
+}
+
+func NewDuration(data time.Duration, presentValid ...bool) Duration {
+	d := Duration{
+		Present: true,
+		Valid:   true,
+		Data:    data,
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+
+	return d
+}
+
+func NewDurationPtr(data time.Duration, presentValid ...bool) *Duration {
+	d := NewDuration(data, presentValid...)
+	return &d
+}
+
+// ParseDuration parses Go, ISO 8601 or postgres interval duration string s into a valid Duration.
+func ParseDuration(s string) (Duration, error) {
+	dur, err := parseDuration(s)
+	if err != nil {
+		return Duration{}, err
+	}
+	return NewDuration(dur), nil
+}
+
+func (d Duration) IsPresent() bool {
+	return d.Present
+}
+
+func (d Duration) IsValid() bool {
+	return d.Valid
+}
+
+func (d Duration) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Duration) IsZero() bool {
+	return !d.Present
+}
+
+func (d Duration) Ptr() *time.Duration {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+// ISO8601 returns the duration in ISO 8601 format, e.g. `PT1H30M`.
+// Only hours, minutes and seconds are used, as days are not always 24 hours long.
+func (d Duration) ISO8601() string {
+	return formatISODuration(d.Data)
+}
+
+var (
+	_ driver.Valuer                    = (*Duration)(nil)
+	_ sql.Scanner                      = (*Duration)(nil)
+	_ json.Marshaler                   = (*Duration)(nil)
+	_ json.Unmarshaler                 = (*Duration)(nil)
+	_ bson.ValueMarshaler              = (*Duration)(nil)
+	_ bson.ValueUnmarshaler            = (*Duration)(nil)
+	_ msgpack.Marshaler                = (*Duration)(nil)
+	_ msgpack.Unmarshaler              = (*Duration)(nil)
+	_ pgtype.IntervalScanner           = (*Duration)(nil)
+	_ pgtype.IntervalValuer            = (*Duration)(nil)
+	_ schema.QueryAppender             = (*Duration)(nil)
+	_ gormschema.GormDataTypeInterface = (*Duration)(nil)
+	_ migrator.GormDataTypeInterface   = (*Duration)(nil)
+)
+
+// Scan implements sql.Scanner interface
+//
+// Integer value is treated as nanoseconds.
+func (d *Duration) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		dur time.Duration
+		err error
+	)
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case int64:
+		dur = time.Duration(v)
+	case []byte:
+		dur, err = parseDuration(string(v))
+	case string:
+		dur, err = parseDuration(v)
+	default:
+		return errors.New(fmt.Sprint("Failed to scan duration value:", value))
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Valid = true
+	d.Data = dur
+	return nil
+}
+
+// Value implements driver.Valuer interface
+//
+// Duration is written in ISO 8601 format, which is accepted by postgres `interval` column.
+func (d Duration) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return formatISODuration(d.Data), nil
+}
+
+// ScanInterval implements pgtype.IntervalScanner interface.
+//
+// Month is converted as 30 days. Interval that overflows time.Duration is rejected.
+func (d *Duration) ScanInterval(v pgtype.Interval) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	months, ok1 := mulDuration(int64(v.Months), durationMonth)
+	days, ok2 := mulDuration(int64(v.Days), durationDay)
+	micros, ok3 := mulDuration(v.Microseconds, time.Microsecond)
+	total, ok4 := addDuration(months, days)
+	total, ok5 := addDuration(total, micros)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return fmt.Errorf("nullable: interval of %d months %d days %d microseconds overflows duration", v.Months, v.Days, v.Microseconds)
+	}
+
+	d.Valid = true
+	d.Data = total
+	return nil
+}
+
+// IntervalValue implements pgtype.IntervalValuer interface.
+//
+// Duration is written as microseconds only, the same as the hours, minutes and seconds of Value.
+func (d Duration) IntervalValue() (pgtype.Interval, error) {
+	return pgtype.Interval{Microseconds: int64(d.Data / time.Microsecond), Valid: d.Valid}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Duration) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Duration) GormDataType() string {
+	return string(gormschema.String)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+//
+// Duration is stored as `interval` in postgres, and as ISO 8601 text in other databases.
+func (Duration) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return gormColumnType{Postgres: "interval", MySQL: "varchar(64)", SQLite: "text", SQLServer: "nvarchar(64)"}.of(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// Duration is marshaled in Go syntax, e.g. `"1h30m0s"`.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Data.String())
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// JSON number is treated as nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var val interface{}
+	if err := json.Unmarshal(data, &val); err != nil {
+		return rejectError(data, "duration", err)
+	}
+
+	switch v := val.(type) {
+	case string:
+		dur, err := parseDuration(v)
+		if err != nil {
+			return rejectError(data, "duration", err)
+		}
+		d.Data = dur
+	case float64:
+		dur, ok := floatDuration(v)
+		if !ok {
+			return rejectError(data, "duration", fmt.Errorf("nullable: duration %s overflows", data))
+		}
+		d.Data = dur
+	default:
+		return rejectError(data, "duration", fmt.Errorf("nullable: invalid duration %s", data))
+	}
+
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// Duration is encoded as BSON int64 nanoseconds, the same as time.Duration in mongo driver.
+func (d Duration) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	t, byt, err := bson.MarshalValue(int64(d.Data))
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Duration) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		raw = bson.RawValue{Type: bson.Type(t), Value: data}
+		err error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil
+	case bson.TypeInt32, bson.TypeInt64:
+		err = d.Scan(raw.AsInt64())
+	case bson.TypeDouble:
+		if dur, ok := floatDuration(raw.Double()); ok {
+			err = d.Scan(int64(dur))
+		} else {
+			err = fmt.Errorf("nullable: duration %v overflows", raw.Double())
+		}
+	case bson.TypeString:
+		err = d.Scan(raw.StringValue())
+	default:
+		err = fmt.Errorf("nullable: unsupported bson type %s for duration", raw.Type)
+	}
+	if err != nil {
+		return rejectError(data, "duration", err)
+	}
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d Duration) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(int64(d.Data))
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *Duration) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val *int64
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	d.Valid = true
+	d.Data = time.Duration(*val)
+	return nil
+}
+
+func (Duration) FiberConverter(value string) reflect.Value {
+	dur, err := parseDuration(value)
+	if err != nil {
+		a := NewDuration(dur, true, false)
+		return reflect.ValueOf(a)
+	}
+	a := NewDuration(dur, true, true)
+	return reflect.ValueOf(a)
+}
+
+// parseDuration parses Go syntax, ISO 8601 or postgres interval duration.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("nullable: empty duration")
+	}
+	if strings.HasPrefix(s, "P") || strings.HasPrefix(s, "-P") {
+		return parseISODuration(s)
+	}
+	if dur, err := time.ParseDuration(s); err == nil {
+		return dur, nil
+	}
+	return parseIntervalDuration(s)
+}
+
+// parseISODuration parses ISO 8601 duration, e.g. `P1DT2H30M` or `PT0.5S`.
+func parseISODuration(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("nullable: invalid ISO 8601 duration %q", s)
+
+	neg := strings.HasPrefix(s, "-")
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "P")
+	if rest == "" || strings.HasSuffix(rest, "T") {
+		// `P`, `PT` and `P1DT` have no component after the designator
+		return 0, invalid
+	}
+
+	var (
+		total  float64
+		inTime bool
+		num    strings.Builder
+	)
+	for _, r := range rest {
+		switch {
+		case r == 'T':
+			if inTime || num.Len() > 0 {
+				return 0, invalid
+			}
+			inTime = true
+		case (r >= '0' && r <= '9') || r == '.' || r == ',':
+			if r == ',' {
+				r = '.'
+			}
+			num.WriteRune(r)
+		default:
+			if num.Len() == 0 {
+				return 0, invalid
+			}
+			n, err := strconv.ParseFloat(num.String(), 64)
+			if err != nil {
+				return 0, invalid
+			}
+			num.Reset()
+
+			var unit time.Duration
+			switch {
+			case !inTime && r == 'Y':
+				unit = durationYear
+			case !inTime && r == 'M':
+				unit = durationMonth
+			case !inTime && r == 'W':
+				unit = durationWeek
+			case !inTime && r == 'D':
+				unit = durationDay
+			case inTime && r == 'H':
+				unit = time.Hour
+			case inTime && r == 'M':
+				unit = time.Minute
+			case inTime && r == 'S':
+				unit = time.Second
+			default:
+				return 0, invalid
+			}
+			total += n * float64(unit)
+		}
+	}
+	// float64(math.MaxInt64) is 2^63, which does not fit into time.Duration
+	if num.Len() > 0 || total >= math.MaxInt64 {
+		return 0, invalid
+	}
+
+	dur := time.Duration(math.Round(total))
+	if neg {
+		dur = -dur
+	}
+	return dur, nil
+}
+
+// parseIntervalDuration parses postgres interval output, e.g. `1 year 2 mons 3 days 04:05:06.789` or `-1 days +02:00:00`.
+func parseIntervalDuration(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("nullable: invalid interval %q", s)
+
+	var total time.Duration
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			clock, err := parseIntervalClock(field)
+			if err != nil {
+				return 0, invalid
+			}
+			sum, ok := addDuration(total, clock)
+			if !ok {
+				return 0, invalid
+			}
+			total = sum
+			continue
+		}
+
+		n, err := strconv.ParseInt(field, 10, 64)
+		if err != nil || i+1 >= len(fields) {
+			return 0, invalid
+		}
+		i++
+
+		var unit time.Duration
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "year":
+			unit = durationYear
+		case "mon", "month":
+			unit = durationMonth
+		case "week":
+			unit = durationWeek
+		case "day":
+			unit = durationDay
+		case "hour":
+			unit = time.Hour
+		case "min", "minute":
+			unit = time.Minute
+		case "sec", "second":
+			unit = time.Second
+		default:
+			return 0, invalid
+		}
+		dur, ok := mulDuration(n, unit)
+		if ok {
+			total, ok = addDuration(total, dur)
+		}
+		if !ok {
+			return 0, invalid
+		}
+	}
+	return total, nil
+}
+
+// parseIntervalClock parses `[+-]HH:MM[:SS[.fraction]]`.
+func parseIntervalClock(s string) (time.Duration, error) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.New("invalid clock")
+	}
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	var sec float64
+	if len(parts) == 3 {
+		if sec, err = strconv.ParseFloat(parts[2], 64); err != nil {
+			return 0, err
+		}
+	}
+
+	hours, ok1 := mulDuration(h, time.Hour)
+	minutes, ok2 := mulDuration(m, time.Minute)
+	secs, ok3 := floatDuration(math.Round(sec * float64(time.Second)))
+	dur, ok4 := addDuration(hours, minutes)
+	dur, ok5 := addDuration(dur, secs)
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 {
+		return 0, errors.New("clock overflows duration")
+	}
+	if neg {
+		dur = -dur
+	}
+	return dur, nil
+}
+
+// mulDuration returns n * unit, or false if it overflows time.Duration.
+func mulDuration(n int64, unit time.Duration) (time.Duration, bool) {
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// addDuration returns a + b, or false if it overflows time.Duration.
+func addDuration(a, b time.Duration) (time.Duration, bool) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, false
+	}
+	return c, true
+}
+
+// floatDuration converts nanoseconds f into time.Duration, or false if it overflows time.Duration.
+func floatDuration(f float64) (time.Duration, bool) {
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, false
+	}
+	return time.Duration(f), true
+}
+
+// formatISODuration formats dur as ISO 8601 duration using hours, minutes and seconds, e.g. `PT1H30M`.
+func formatISODuration(dur time.Duration) string {
+	if dur == 0 {
+		return "PT0S"
+	}
+
+	// abs is unsigned, as -dur overflows for math.MinInt64
+	var b strings.Builder
+	abs := uint64(dur)
+	if dur < 0 {
+		b.WriteByte('-')
+		abs = -abs
+	}
+	b.WriteString("PT")
+
+	if h := abs / uint64(time.Hour); h > 0 {
+		b.WriteString(strconv.FormatUint(h, 10))
+		b.WriteByte('H')
+		abs -= h * uint64(time.Hour)
+	}
+	if m := abs / uint64(time.Minute); m > 0 {
+		b.WriteString(strconv.FormatUint(m, 10))
+		b.WriteByte('M')
+		abs -= m * uint64(time.Minute)
+	}
+	if abs > 0 {
+		b.WriteString(strconv.FormatFloat(time.Duration(abs).Seconds(), 'f', -1, 64))
+		b.WriteByte('S')
+	}
+	return b.String()
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"encoding/json"
+)
+
+type durationJsonTest struct {
+	Value Duration `json:"value,omitzero"`
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   durationJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   durationJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: durationJsonTest{
+				Value: Duration{
+					Present: true,
+					Valid:   false,
+				},
+			},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name: "valid value",
+			data: durationJsonTest{
+				Value: NewDuration(90 * time.Minute),
+			},
+			expect: bytes.NewBufferString(`{"value":"1h30m0s"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestDuration_Parse(t *testing.T) {
+	tests := []struct {
+		value  string
+		expect time.Duration
+	}{
+		{value: "1h30m", expect: 90 * time.Minute},
+		{value: "PT1H30M", expect: 90 * time.Minute},
+		{value: "P1DT0.5S", expect: 24*time.Hour + 500*time.Millisecond},
+		{value: "-PT15M", expect: -15 * time.Minute},
+		{value: "01:30:00", expect: 90 * time.Minute},
+		{value: "1 day 02:00:00", expect: 26 * time.Hour},
+		{value: "-1 days +02:00:00", expect: -22 * time.Hour},
+		{value: "1 mon", expect: 30 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDuration(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+			if got.Data != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, got.Data)
+			}
+		})
+	}
+}
+
+func TestDuration_ParseInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"P",
+		"PT",
+		"-PT",
+		"P1DT",
+		"PT1H2",
+		"PT1D",
+	}
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			if got, err := ParseDuration(value); err == nil {
+				t.Errorf("expected parse error got %s", got.Data)
+			}
+		})
+	}
+}
+
+func TestDuration_Overflow(t *testing.T) {
+	tests := []string{
+		"300000 years",
+		"-300000 years",
+		"200000 years 200000 years",
+		"106751 days 23:47:16.854775808",
+		"3000000:00:00",
+		"P300000Y",
+		"PT9223372036.854775808S",
+	}
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			if got, err := ParseDuration(value); err == nil {
+				t.Errorf("expected overflow error got %s", got.Data)
+			}
+		})
+	}
+
+	var got Duration
+	if err := json.Unmarshal([]byte(`1e19`), &got); err == nil {
+		t.Errorf("expected overflow error unmarshaling JSON number got %s", got.Data)
+	}
+	if err := got.ScanInterval(pgtype.Interval{Months: math.MaxInt32, Valid: true}); err == nil {
+		t.Errorf("expected overflow error scanning interval got %s", got.Data)
+	}
+
+	max, err := ParseDuration("106751 days 23:47:16.854775807")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %s", err)
+	}
+	if max.Data != math.MaxInt64 {
+		t.Errorf("expected value to be %s got %s", time.Duration(math.MaxInt64), max.Data)
+	}
+}
+
+func TestDuration_Value(t *testing.T) {
+	tests := []struct {
+		data   time.Duration
+		expect string
+	}{
+		{data: 0, expect: "PT0S"},
+		{data: 90 * time.Minute, expect: "PT1H30M"},
+		{data: 36*time.Hour + 1500*time.Millisecond, expect: "PT36H1.5S"},
+		{data: -15 * time.Minute, expect: "-PT15M"},
+		{data: math.MaxInt64, expect: "PT2562047H47M16.854775807S"},
+		{data: math.MinInt64, expect: "-PT2562047H47M16.854775808S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expect, func(t *testing.T) {
+			got, err := NewDuration(tt.data).Value()
+			if err != nil {
+				t.Fatalf("unexpected value error: %s", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected value to be %s got %v", tt.expect, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNumberOverflow is returned when a value does not fit into the data type of Number.
+var ErrNumberOverflow = errors.New("nullable: number overflow")
+
+// StrictDecoding controls how invalid input is handled by UnmarshalJSON and UnmarshalBSONValue
+// of String, Int, Float, Bool, Decimal and Number.
+//
+// By default, a value that can not be decoded into the expected type is treated as null
+// (Present is true, Valid is false). When StrictDecoding is true, a *DecodeError is returned instead.
+// A Number that overflows its data type always returns ErrNumberOverflow.
+//
+// The other types always reject invalid input with a *DecodeError, regardless of StrictDecoding,
+// and UnmarshalMsgpack returns the error in both modes.
+//
+// It should be set once, before any decoding takes place.
+var StrictDecoding = false
+
+// DecodeError describes a value that can not be decoded into the expected type.
+type DecodeError struct {
+	Raw  []byte // Raw is the raw bytes of the field
+	Kind string // Kind is the expected kind of the value
+	Err  error  // Err is the underlying decoding error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("nullable: cannot decode %q into %s: %v", e.Raw, e.Kind, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError returns *DecodeError when StrictDecoding is enabled, otherwise nil.
+func decodeError(data []byte, kind string, err error) error {
+	if !StrictDecoding {
+		return nil
+	}
+	return rejectError(data, kind, err)
+}
+
+// rejectError returns *DecodeError regardless of StrictDecoding.
+func rejectError(data []byte, kind string, err error) error {
+	raw := make([]byte, len(data))
+	copy(raw, data)
+	return &DecodeError{
+		Raw:  raw,
+		Kind: kind,
+		Err:  err,
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		buf    []byte
+		value  json.Unmarshaler
+		kind   string
+		strict bool
+	}{
+		{
+			name:  "int not strict",
+			buf:   []byte(`"abc"`),
+			value: &Int{},
+		},
+		{
+			name:   "int strict",
+			buf:    []byte(`"abc"`),
+			value:  &Int{},
+			kind:   "int64",
+			strict: true,
+		},
+		{
+			name:   "string strict",
+			buf:    []byte(`123`),
+			value:  &String{},
+			kind:   "string",
+			strict: true,
+		},
+		{
+			name:   "float strict",
+			buf:    []byte(`true`),
+			value:  &Float{},
+			kind:   "float64",
+			strict: true,
+		},
+		{
+			name:   "bool strict",
+			buf:    []byte(`"yes"`),
+			value:  &Bool{},
+			kind:   "bool",
+			strict: true,
+		},
+		{
+			name:  "date not strict",
+			buf:   []byte(`"2024-13-45"`),
+			value: &Date{},
+			kind:  "date",
+		},
+		{
+			name:   "date strict",
+			buf:    []byte(`"2024-13-45"`),
+			value:  &Date{},
+			kind:   "date",
+			strict: true,
+		},
+		{
+			name:   "time of day strict",
+			buf:    []byte(`"25:00"`),
+			value:  &TimeOfDay{},
+			kind:   "time of day",
+			strict: true,
+		},
+		{
+			name:   "duration strict",
+			buf:    []byte(`true`),
+			value:  &Duration{},
+			kind:   "duration",
+			strict: true,
+		},
+		{
+			name:   "uuid strict",
+			buf:    []byte(`"abc"`),
+			value:  &UUID{},
+			kind:   "uuid",
+			strict: true,
+		},
+		{
+			name:  "duration not strict",
+			buf:   []byte(`1e19`),
+			value: &Duration{},
+			kind:  "duration",
+		},
+		{
+			name:  "uuid not strict",
+			buf:   []byte(`"abc"`),
+			value: &UUID{},
+			kind:  "uuid",
+		},
+		{
+			name:  "time not strict",
+			buf:   []byte(`"abc"`),
+			value: &Time{},
+			kind:  "time",
+		},
+		{
+			name:  "unix time not strict",
+			buf:   []byte(`"abc"`),
+			value: &UnixTime{},
+			kind:  "unix time",
+		},
+		{
+			name:  "string array not strict",
+			buf:   []byte(`"abc"`),
+			value: &StringArray{},
+			kind:  "string array",
+		},
+		{
+			name:  "type not strict",
+			buf:   []byte(`"abc"`),
+			value: &Type[[]int]{},
+			kind:  "[]int",
+		},
+		{
+			name:  "array not strict",
+			buf:   []byte(`"abc"`),
+			value: &Array[int64]{},
+			kind:  "[]int64",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			StrictDecoding = tt.strict
+			defer func() { StrictDecoding = false }()
+
+			// Types out of the scope of StrictDecoding always return *DecodeError.
+			err := tt.value.UnmarshalJSON(tt.buf)
+			if tt.kind == "" {
+				if err != nil {
+					t.Fatalf("unexpected unmarshaling error: %s", err)
+				}
+				return
+			}
+
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("expected *DecodeError got %v", err)
+			}
+			if decodeErr.Kind != tt.kind || string(decodeErr.Raw) != string(tt.buf) {
+				t.Errorf("expected kind %s and raw %s got %s and %s", tt.kind, tt.buf, decodeErr.Kind, decodeErr.Raw)
+			}
+		})
+	}
+}
+
+func TestStrictDecoding_BSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		dst    func() bson.ValueUnmarshaler
+		kind   string
+		reject bool // reject is true if the error is returned regardless of StrictDecoding
+	}{
+		{name: "int", value: "abc", dst: func() bson.ValueUnmarshaler { return &Int{} }, kind: "int64"},
+		{name: "string", value: 123, dst: func() bson.ValueUnmarshaler { return &String{} }, kind: "string"},
+		{name: "float", value: "abc", dst: func() bson.ValueUnmarshaler { return &Float{} }, kind: "float64"},
+		{name: "bool", value: "yes", dst: func() bson.ValueUnmarshaler { return &Bool{} }, kind: "bool"},
+		{name: "date", value: "2024-13-45", dst: func() bson.ValueUnmarshaler { return &Date{} }, kind: "date", reject: true},
+		{name: "time of day", value: int32(1), dst: func() bson.ValueUnmarshaler { return &TimeOfDay{} }, kind: "time of day", reject: true},
+		{name: "duration", value: "abc", dst: func() bson.ValueUnmarshaler { return &Duration{} }, kind: "duration", reject: true},
+		{name: "uuid", value: "abc", dst: func() bson.ValueUnmarshaler { return &UUID{} }, kind: "uuid", reject: true},
+		{name: "time", value: "abc", dst: func() bson.ValueUnmarshaler { return &Time{} }, kind: "time", reject: true},
+		{name: "unix milli time", value: true, dst: func() bson.ValueUnmarshaler { return &UnixMilliTime{} }, kind: "unix milli time", reject: true},
+		{name: "string array", value: "abc", dst: func() bson.ValueUnmarshaler { return &StringArray{} }, kind: "string array", reject: true},
+	}
+
+	for _, tt := range tests {
+		typ, data, err := bson.MarshalValue(tt.value)
+		if err != nil {
+			t.Fatalf("unexpected marshaling error: %s", err)
+		}
+
+		t.Run(tt.name+" not strict", func(t *testing.T) {
+			dst := tt.dst()
+			err := dst.UnmarshalBSONValue(byte(typ), data)
+			if tt.reject {
+				var decodeErr *DecodeError
+				if !errors.As(err, &decodeErr) || decodeErr.Kind != tt.kind {
+					t.Fatalf("expected *DecodeError of kind %s got %v", tt.kind, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if n := dst.(Nullable); !n.IsPresent() || n.IsValid() {
+				t.Errorf("expected present null value got %+v", dst)
+			}
+		})
+
+		t.Run(tt.name+" strict", func(t *testing.T) {
+			StrictDecoding = true
+			defer func() { StrictDecoding = false }()
+
+			var decodeErr *DecodeError
+			if err := tt.dst().UnmarshalBSONValue(byte(typ), data); !errors.As(err, &decodeErr) {
+				t.Fatalf("expected *DecodeError got %v", err)
+			}
+			if decodeErr.Kind != tt.kind || string(decodeErr.Raw) != string(data) {
+				t.Errorf("expected kind %s and raw %v got %s and %v", tt.kind, data, decodeErr.Kind, decodeErr.Raw)
+			}
+		})
+	}
+}
+
+func TestStrictDecoding_Msgpack(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		dst   func() msgpack.Unmarshaler
+	}{
+		{name: "int", value: "abc", dst: func() msgpack.Unmarshaler { return &Int{} }},
+		{name: "string", value: 123, dst: func() msgpack.Unmarshaler { return &String{} }},
+		{name: "date", value: "2024-13-45", dst: func() msgpack.Unmarshaler { return &Date{} }},
+		{name: "uuid", value: "abc", dst: func() msgpack.Unmarshaler { return &UUID{} }},
+	}
+
+	for _, tt := range tests {
+		data, err := msgpack.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("unexpected marshaling error: %s", err)
+		}
+
+		// msgpack returns the error in both modes.
+		for _, strict := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				StrictDecoding = strict
+				defer func() { StrictDecoding = false }()
+
+				if err := tt.dst().UnmarshalMsgpack(data); err == nil {
+					t.Errorf("expected unmarshaling error with strict %t, got nil", strict)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"reflect"
+	"strings"
+)
+
+var nullableType = reflect.TypeFor[Nullable]()
+
+// nullableField is a struct field that implements Nullable interface.
+type nullableField struct {
+	Name     string // Name is the go struct field name
+	Tag      string // Tag is the name from the struct tag, empty if not set
+	Field    reflect.StructField
+	Value    reflect.Value
+	Nullable Nullable
+}
+
+// Key returns the tag name of the field, or the struct field name if the tag is not set.
+func (f nullableField) Key() string {
+	if f.Tag != "" {
+		return f.Tag
+	}
+	return f.Name
+}
+
+// tagName returns the name part of struct tag key, and whether the field should be skipped.
+func tagName(field reflect.StructField, key string) (string, bool) {
+	tag, ok := field.Tag.Lookup(key)
+	if !ok {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", true
+	}
+	return name, false
+}
+
+// nullableFields returns all exported fields of struct v that implement Nullable interface.
+// Fields of embedded struct are returned as if they belong to v.
+// Nil pointer and nil interface fields are skipped, as they are never present.
+func nullableFields(v reflect.Value, tagKey string) []nullableField {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []nullableField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, skip := tagName(sf, tagKey)
+		if skip {
+			continue
+		}
+
+		fv := v.Field(i)
+		if !sf.Type.Implements(nullableType) {
+			if sf.Anonymous && (fv.Kind() == reflect.Struct || (fv.Kind() == reflect.Pointer && !fv.IsNil())) {
+				fields = append(fields, nullableFields(fv, tagKey)...)
+			}
+			continue
+		}
+
+		if fv.Kind() == reflect.Interface {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		fields = append(fields, nullableField{
+			Name:     sf.Name,
+			Tag:      tag,
+			Field:    sf,
+			Value:    fv,
+			Nullable: fv.Interface().(Nullable),
+		})
+	}
+	return fields
+}
+
+// hasNullableFields reports whether struct type t has any field that implements Nullable interface.
+func hasNullableFields(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Type.Implements(nullableType) {
+			return true
+		}
+		if sf.Anonymous && hasNullableFields(sf.Type) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,8 +14,13 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 
 	"encoding/json"
 
@@ -63,6 +68,12 @@ func (d Float) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Float) IsZero() bool {
+	return !d.Present
+}
+
 func (d Float) Null() null.Float {
 	return null.NewFloat(d.Data, d.Present && d.Valid)
 }
@@ -75,14 +86,19 @@ func (d Float) Ptr() *float64 {
 }
 
 var (
-	_ driver.Valuer       = (*Float)(nil)
-	_ sql.Scanner         = (*Float)(nil)
-	_ json.Marshaler      = (*Float)(nil)
-	_ json.Unmarshaler    = (*Float)(nil)
-	_ bson.Marshaler      = (*Float)(nil)
-	_ bson.Unmarshaler    = (*Float)(nil)
-	_ msgpack.Marshaler   = (*Float)(nil)
-	_ msgpack.Unmarshaler = (*Float)(nil)
+	_ driver.Valuer                    = (*Float)(nil)
+	_ sql.Scanner                      = (*Float)(nil)
+	_ json.Marshaler                   = (*Float)(nil)
+	_ json.Unmarshaler                 = (*Float)(nil)
+	_ bson.ValueMarshaler              = (*Float)(nil)
+	_ bson.ValueUnmarshaler            = (*Float)(nil)
+	_ msgpack.Marshaler                = (*Float)(nil)
+	_ msgpack.Unmarshaler              = (*Float)(nil)
+	_ pgtype.Float64Scanner            = (*Float)(nil)
+	_ pgtype.Float64Valuer             = (*Float)(nil)
+	_ schema.QueryAppender             = (*Float)(nil)
+	_ gormschema.GormDataTypeInterface = (*Float)(nil)
+	_ migrator.GormDataTypeInterface   = (*Float)(nil)
 )
 
 // Scan implements sql.Scanner interface
@@ -106,6 +122,34 @@ func (d Float) Value() (driver.Value, error) {
 	return d.Data, nil
 }
 
+// ScanFloat64 implements pgtype.Float64Scanner interface.
+func (d *Float) ScanFloat64(v pgtype.Float8) error {
+	d.Present = true
+	d.Valid = v.Valid
+	d.Data = v.Float64
+	return nil
+}
+
+// Float64Value implements pgtype.Float64Valuer interface.
+func (d Float) Float64Value() (pgtype.Float8, error) {
+	return pgtype.Float8{Float64: d.Data, Valid: d.Valid}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Float) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Float) GormDataType() string {
+	return string(gormschema.Float)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Float) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return gormFloatDataType(db, field, 64)
+}
+
 // MarshalJSON implements json.Marshaler interface.
 func (d Float) MarshalJSON() ([]byte, error) {
 	if !d.Present {
@@ -125,36 +169,34 @@ func (d *Float) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &d.Data); err != nil {
-		return nil
+		return decodeError(data, "float64", err)
 	}
 
 	d.Valid = true
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d Float) MarshalBSON() (byt []byte, err error) {
-	var tmp *float64
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d Float) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
 	}
-	_, byt, err = bson.MarshalValue(d.Data)
-	return byt, err
+	t, byt, err := bson.MarshalValue(d.Data)
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *Float) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Float) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
 		return nil
 	}
 
-	if err := bson.Unmarshal(data, &d.Data); err != nil {
-		return nil
+	if err := raw.Unmarshal(&d.Data); err != nil {
+		return decodeError(data, "float64", err)
 	}
 
 	d.Valid = true
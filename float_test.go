@@ -15,7 +15,7 @@ import (
 )
 
 type floatJsonTest struct {
-	Value Float `json:"value"`
+	Value Float `json:"value,omitzero"`
 }
 
 func TestFloat_MarshalJSON(t *testing.T) {
@@ -24,6 +24,11 @@ func TestFloat_MarshalJSON(t *testing.T) {
 		data   floatJsonTest
 		expect *bytes.Buffer
 	}{
+		{
+			name:   "undefined value",
+			data:   floatJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
 		{
 			name: "null value",
 			data: floatJsonTest{
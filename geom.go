@@ -8,177 +8,1837 @@
 package nullable
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/paulmach/orb"
 	"github.com/paulmach/orb/encoding/ewkb"
+	"github.com/paulmach/orb/encoding/wkt"
 	"github.com/paulmach/orb/geojson"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 )
 
+// DefaultSRID is the SRID written into EWKB by the driver.Valuer of geometry types.
+// The default is 4326 (WGS 84).
+//
+// It should be set once, before any query takes place.
+var DefaultSRID = 4326
+
+// GeomPoint represents a geometry point that may be null or not
+// present in JSON at all.
 type GeomPoint struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
 	geojson.Point
 }
 
-var (
-	_ sql.Scanner      = (*GeomPoint)(nil)
-	_ json.Unmarshaler = (*GeomPoint)(nil)
-	_ bson.Unmarshaler = (*GeomPoint)(nil)
-)
+func NewGeomPoint(data orb.Point, presentValid ...bool) GeomPoint {
+	d := GeomPoint{
+		Present: true,
+		Valid:   true,
+		Point:   geojson.Point(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewGeomPointPtr(data orb.Point, presentValid ...bool) *GeomPoint {
+	d := NewGeomPoint(data, presentValid...)
+	return &d
+}
+
+func (g GeomPoint) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomPoint) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomPoint) GetValue() interface{} {
+	return orb.Point(g.Point)
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomPoint) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomPoint) Ptr() *orb.Point {
+	if g.Valid {
+		p := orb.Point(g.Point)
+		return &p
+	}
+	return nil
+}
+
+// String returns the point as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;POINT(106.8 -6.2)`.
+// It returns empty string if the value is not valid.
+func (g GeomPoint) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*GeomPoint)(nil)
+	_ sql.Scanner                      = (*GeomPoint)(nil)
+	_ json.Marshaler                   = (*GeomPoint)(nil)
+	_ json.Unmarshaler                 = (*GeomPoint)(nil)
+	_ encoding.TextMarshaler           = (*GeomPoint)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomPoint)(nil)
+	_ bson.ValueMarshaler              = (*GeomPoint)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomPoint)(nil)
+	_ bson.Marshaler                   = (*GeomPoint)(nil)
+	_ bson.Unmarshaler                 = (*GeomPoint)(nil)
+	_ schema.QueryAppender             = (*GeomPoint)(nil)
+	_ pgtype.BytesScanner              = (*GeomPoint)(nil)
+	_ pgtype.BytesValuer               = (*GeomPoint)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomPoint)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomPoint)(nil)
+	_ gorm.Valuer                      = (*GeomPoint)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomPoint) fields() geometryFields[orb.Point] {
+	return geometryFields[orb.Point]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: (*orb.Point)(&g.Point)}
+}
+
+// Scan implements sql.Scanner interface
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomPoint) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomPoint is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomPoint) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomPoint) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomPoint) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomPoint) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomPoint) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomPoint) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "Point")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomPoint) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomPoint is encoded as GeoJSON geometry object.
+func (g GeomPoint) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomPoint) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomPoint) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomPoint) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomPoint is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomPoint) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomPoint) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomPoint is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomPoint) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomPoint) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// GeomMultiPoint represents a geometry multi point that may be null or not
+// present in JSON at all.
+type GeomMultiPoint struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	geojson.MultiPoint
+}
+
+func NewGeomMultiPoint(data orb.MultiPoint, presentValid ...bool) GeomMultiPoint {
+	d := GeomMultiPoint{
+		Present:    true,
+		Valid:      true,
+		MultiPoint: geojson.MultiPoint(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewGeomMultiPointPtr(data orb.MultiPoint, presentValid ...bool) *GeomMultiPoint {
+	d := NewGeomMultiPoint(data, presentValid...)
+	return &d
+}
+
+func (g GeomMultiPoint) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomMultiPoint) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomMultiPoint) GetValue() interface{} {
+	return orb.MultiPoint(g.MultiPoint)
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomMultiPoint) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomMultiPoint) Ptr() *orb.MultiPoint {
+	if g.Valid {
+		p := orb.MultiPoint(g.MultiPoint)
+		return &p
+	}
+	return nil
+}
+
+// String returns the multi point as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;MULTIPOINT((106.8 -6.2),(106.9 -6.3))`.
+// It returns empty string if the value is not valid.
+func (g GeomMultiPoint) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*GeomMultiPoint)(nil)
+	_ sql.Scanner                      = (*GeomMultiPoint)(nil)
+	_ json.Marshaler                   = (*GeomMultiPoint)(nil)
+	_ json.Unmarshaler                 = (*GeomMultiPoint)(nil)
+	_ encoding.TextMarshaler           = (*GeomMultiPoint)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomMultiPoint)(nil)
+	_ bson.ValueMarshaler              = (*GeomMultiPoint)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomMultiPoint)(nil)
+	_ bson.Marshaler                   = (*GeomMultiPoint)(nil)
+	_ bson.Unmarshaler                 = (*GeomMultiPoint)(nil)
+	_ schema.QueryAppender             = (*GeomMultiPoint)(nil)
+	_ pgtype.BytesScanner              = (*GeomMultiPoint)(nil)
+	_ pgtype.BytesValuer               = (*GeomMultiPoint)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomMultiPoint)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomMultiPoint)(nil)
+	_ gorm.Valuer                      = (*GeomMultiPoint)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomMultiPoint) fields() geometryFields[orb.MultiPoint] {
+	return geometryFields[orb.MultiPoint]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: (*orb.MultiPoint)(&g.MultiPoint)}
+}
+
+// Scan implements sql.Scanner interface
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomMultiPoint) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomMultiPoint is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomMultiPoint) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomMultiPoint) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomMultiPoint) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomMultiPoint) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomMultiPoint) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomMultiPoint) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "MultiPoint")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomMultiPoint) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomMultiPoint is encoded as GeoJSON geometry object.
+func (g GeomMultiPoint) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomMultiPoint) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomMultiPoint) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomMultiPoint) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomMultiPoint is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomMultiPoint) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomMultiPoint) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomMultiPoint is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomMultiPoint) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomMultiPoint) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// GeomLineString represents a geometry line string that may be null or not
+// present in JSON at all.
+type GeomLineString struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	geojson.LineString
+}
+
+func NewGeomLineString(data orb.LineString, presentValid ...bool) GeomLineString {
+	d := GeomLineString{
+		Present:    true,
+		Valid:      true,
+		LineString: geojson.LineString(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewGeomLineStringPtr(data orb.LineString, presentValid ...bool) *GeomLineString {
+	d := NewGeomLineString(data, presentValid...)
+	return &d
+}
+
+func (g GeomLineString) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomLineString) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomLineString) GetValue() interface{} {
+	return orb.LineString(g.LineString)
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomLineString) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomLineString) Ptr() *orb.LineString {
+	if g.Valid {
+		p := orb.LineString(g.LineString)
+		return &p
+	}
+	return nil
+}
+
+// String returns the line string as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;LINESTRING(106.8 -6.2,106.9 -6.3)`.
+// It returns empty string if the value is not valid.
+func (g GeomLineString) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*GeomLineString)(nil)
+	_ sql.Scanner                      = (*GeomLineString)(nil)
+	_ json.Marshaler                   = (*GeomLineString)(nil)
+	_ json.Unmarshaler                 = (*GeomLineString)(nil)
+	_ encoding.TextMarshaler           = (*GeomLineString)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomLineString)(nil)
+	_ bson.ValueMarshaler              = (*GeomLineString)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomLineString)(nil)
+	_ bson.Marshaler                   = (*GeomLineString)(nil)
+	_ bson.Unmarshaler                 = (*GeomLineString)(nil)
+	_ schema.QueryAppender             = (*GeomLineString)(nil)
+	_ pgtype.BytesScanner              = (*GeomLineString)(nil)
+	_ pgtype.BytesValuer               = (*GeomLineString)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomLineString)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomLineString)(nil)
+	_ gorm.Valuer                      = (*GeomLineString)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomLineString) fields() geometryFields[orb.LineString] {
+	return geometryFields[orb.LineString]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: (*orb.LineString)(&g.LineString)}
+}
+
+// Scan implements sql.Scanner interface
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomLineString) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomLineString is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomLineString) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomLineString) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomLineString) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomLineString) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomLineString) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomLineString) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "LineString")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomLineString) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomLineString is encoded as GeoJSON geometry object.
+func (g GeomLineString) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomLineString) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomLineString) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomLineString) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomLineString is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomLineString) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomLineString) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomLineString is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomLineString) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomLineString) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// GeomMultiLineString represents a geometry multi line string that may be null or not
+// present in JSON at all.
+type GeomMultiLineString struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	geojson.MultiLineString
+}
+
+func NewGeomMultiLineString(data orb.MultiLineString, presentValid ...bool) GeomMultiLineString {
+	d := GeomMultiLineString{
+		Present:         true,
+		Valid:           true,
+		MultiLineString: geojson.MultiLineString(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewGeomMultiLineStringPtr(data orb.MultiLineString, presentValid ...bool) *GeomMultiLineString {
+	d := NewGeomMultiLineString(data, presentValid...)
+	return &d
+}
+
+func (g GeomMultiLineString) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomMultiLineString) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomMultiLineString) GetValue() interface{} {
+	return orb.MultiLineString(g.MultiLineString)
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomMultiLineString) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomMultiLineString) Ptr() *orb.MultiLineString {
+	if g.Valid {
+		p := orb.MultiLineString(g.MultiLineString)
+		return &p
+	}
+	return nil
+}
+
+// String returns the multi line string as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;MULTILINESTRING((106.8 -6.2,106.9 -6.3))`.
+// It returns empty string if the value is not valid.
+func (g GeomMultiLineString) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*GeomMultiLineString)(nil)
+	_ sql.Scanner                      = (*GeomMultiLineString)(nil)
+	_ json.Marshaler                   = (*GeomMultiLineString)(nil)
+	_ json.Unmarshaler                 = (*GeomMultiLineString)(nil)
+	_ encoding.TextMarshaler           = (*GeomMultiLineString)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomMultiLineString)(nil)
+	_ bson.ValueMarshaler              = (*GeomMultiLineString)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomMultiLineString)(nil)
+	_ bson.Marshaler                   = (*GeomMultiLineString)(nil)
+	_ bson.Unmarshaler                 = (*GeomMultiLineString)(nil)
+	_ schema.QueryAppender             = (*GeomMultiLineString)(nil)
+	_ pgtype.BytesScanner              = (*GeomMultiLineString)(nil)
+	_ pgtype.BytesValuer               = (*GeomMultiLineString)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomMultiLineString)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomMultiLineString)(nil)
+	_ gorm.Valuer                      = (*GeomMultiLineString)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomMultiLineString) fields() geometryFields[orb.MultiLineString] {
+	return geometryFields[orb.MultiLineString]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: (*orb.MultiLineString)(&g.MultiLineString)}
+}
+
+// Scan implements sql.Scanner interface
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomMultiLineString) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomMultiLineString is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomMultiLineString) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomMultiLineString) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomMultiLineString) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomMultiLineString) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomMultiLineString) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomMultiLineString) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "MultiLineString")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomMultiLineString) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomMultiLineString is encoded as GeoJSON geometry object.
+func (g GeomMultiLineString) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomMultiLineString) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomMultiLineString) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomMultiLineString) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomMultiLineString is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomMultiLineString) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomMultiLineString) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomMultiLineString is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomMultiLineString) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomMultiLineString) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// GeomPolygon represents a geometry polygon that may be null or not
+// present in JSON at all.
+type GeomPolygon struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	geojson.Polygon
+}
+
+func NewGeomPolygon(data orb.Polygon, presentValid ...bool) GeomPolygon {
+	d := GeomPolygon{
+		Present: true,
+		Valid:   true,
+		Polygon: geojson.Polygon(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewGeomPolygonPtr(data orb.Polygon, presentValid ...bool) *GeomPolygon {
+	d := NewGeomPolygon(data, presentValid...)
+	return &d
+}
+
+func (g GeomPolygon) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomPolygon) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomPolygon) GetValue() interface{} {
+	return orb.Polygon(g.Polygon)
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomPolygon) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomPolygon) Ptr() *orb.Polygon {
+	if g.Valid {
+		p := orb.Polygon(g.Polygon)
+		return &p
+	}
+	return nil
+}
+
+// String returns the polygon as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;POLYGON((106.8 -6.2,106.9 -6.3,106.8 -6.3,106.8 -6.2))`.
+// It returns empty string if the value is not valid.
+func (g GeomPolygon) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*GeomPolygon)(nil)
+	_ sql.Scanner                      = (*GeomPolygon)(nil)
+	_ json.Marshaler                   = (*GeomPolygon)(nil)
+	_ json.Unmarshaler                 = (*GeomPolygon)(nil)
+	_ encoding.TextMarshaler           = (*GeomPolygon)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomPolygon)(nil)
+	_ bson.ValueMarshaler              = (*GeomPolygon)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomPolygon)(nil)
+	_ bson.Marshaler                   = (*GeomPolygon)(nil)
+	_ bson.Unmarshaler                 = (*GeomPolygon)(nil)
+	_ schema.QueryAppender             = (*GeomPolygon)(nil)
+	_ pgtype.BytesScanner              = (*GeomPolygon)(nil)
+	_ pgtype.BytesValuer               = (*GeomPolygon)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomPolygon)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomPolygon)(nil)
+	_ gorm.Valuer                      = (*GeomPolygon)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomPolygon) fields() geometryFields[orb.Polygon] {
+	return geometryFields[orb.Polygon]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: (*orb.Polygon)(&g.Polygon)}
+}
+
+// Scan implements sql.Scanner interface
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomPolygon) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomPolygon is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomPolygon) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomPolygon) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomPolygon) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomPolygon) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomPolygon) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomPolygon) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "Polygon")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomPolygon) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomPolygon is encoded as GeoJSON geometry object.
+func (g GeomPolygon) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomPolygon) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomPolygon) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomPolygon) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomPolygon is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomPolygon) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomPolygon) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomPolygon is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomPolygon) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomPolygon) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// GeomMultiPolygon represents a geometry multi polygon that may be null or not
+// present in JSON at all.
+type GeomMultiPolygon struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	geojson.MultiPolygon
+}
+
+func NewGeomMultiPolygon(data orb.MultiPolygon, presentValid ...bool) GeomMultiPolygon {
+	d := GeomMultiPolygon{
+		Present:      true,
+		Valid:        true,
+		MultiPolygon: geojson.MultiPolygon(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewGeomMultiPolygonPtr(data orb.MultiPolygon, presentValid ...bool) *GeomMultiPolygon {
+	d := NewGeomMultiPolygon(data, presentValid...)
+	return &d
+}
+
+func (g GeomMultiPolygon) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomMultiPolygon) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomMultiPolygon) GetValue() interface{} {
+	return orb.MultiPolygon(g.MultiPolygon)
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomMultiPolygon) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomMultiPolygon) Ptr() *orb.MultiPolygon {
+	if g.Valid {
+		p := orb.MultiPolygon(g.MultiPolygon)
+		return &p
+	}
+	return nil
+}
+
+// String returns the multi polygon as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;MULTIPOLYGON(((106.8 -6.2,106.9 -6.3,106.8 -6.3,106.8 -6.2)))`.
+// It returns empty string if the value is not valid.
+func (g GeomMultiPolygon) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*GeomMultiPolygon)(nil)
+	_ sql.Scanner                      = (*GeomMultiPolygon)(nil)
+	_ json.Marshaler                   = (*GeomMultiPolygon)(nil)
+	_ json.Unmarshaler                 = (*GeomMultiPolygon)(nil)
+	_ encoding.TextMarshaler           = (*GeomMultiPolygon)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomMultiPolygon)(nil)
+	_ bson.ValueMarshaler              = (*GeomMultiPolygon)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomMultiPolygon)(nil)
+	_ bson.Marshaler                   = (*GeomMultiPolygon)(nil)
+	_ bson.Unmarshaler                 = (*GeomMultiPolygon)(nil)
+	_ schema.QueryAppender             = (*GeomMultiPolygon)(nil)
+	_ pgtype.BytesScanner              = (*GeomMultiPolygon)(nil)
+	_ pgtype.BytesValuer               = (*GeomMultiPolygon)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomMultiPolygon)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomMultiPolygon)(nil)
+	_ gorm.Valuer                      = (*GeomMultiPolygon)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomMultiPolygon) fields() geometryFields[orb.MultiPolygon] {
+	return geometryFields[orb.MultiPolygon]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: (*orb.MultiPolygon)(&g.MultiPolygon)}
+}
+
+// Scan implements sql.Scanner interface
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomMultiPolygon) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomMultiPolygon is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomMultiPolygon) Value() (driver.Value, error) {
+	return g.fields().value()
+}
 
-// Scan implements sql.Scanner interface
-func (g *GeomPoint) Scan(input interface{}) error {
-	var data []byte
-	switch v := input.(type) {
-	case []byte:
-		data = v
-	case string:
-		data = []byte(v)
-	default:
-		return fmt.Errorf("invalid geometry type: get %v", v)
-	}
-	p := orb.Point{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
-	if err != nil {
-		return err
-	}
-	g.Point = geojson.Point(p)
-	return nil
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomMultiPolygon) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomMultiPolygon) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomMultiPolygon) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomMultiPolygon) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomMultiPolygon) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "MultiPolygon")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomMultiPolygon) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomMultiPolygon is encoded as GeoJSON geometry object.
+func (g GeomMultiPolygon) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Marshaler interface.
-func (g *GeomPoint) UnmarshalJSON(data []byte) error {
-	p := orb.Point{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
-	if err != nil {
-		return err
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomMultiPolygon) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomMultiPolygon) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomMultiPolygon) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomMultiPolygon is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomMultiPolygon) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomMultiPolygon) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomMultiPolygon is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomMultiPolygon) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomMultiPolygon) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// GeomCollection represents a geometry collection that may be null or not
+// present in JSON at all.
+type GeomCollection struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	Data    orb.Collection
+}
+
+func NewGeomCollection(data orb.Collection, presentValid ...bool) GeomCollection {
+	d := GeomCollection{
+		Present: true,
+		Valid:   true,
+		Data:    data,
 	}
-	g.Point = geojson.Point(p)
-	return nil
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (g *GeomPoint) UnmarshalBSON(data []byte) error {
-	p := orb.Point{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
-	if err != nil {
-		return err
+func NewGeomCollectionPtr(data orb.Collection, presentValid ...bool) *GeomCollection {
+	d := NewGeomCollection(data, presentValid...)
+	return &d
+}
+
+func (g GeomCollection) IsPresent() bool {
+	return g.Present
+}
+
+func (g GeomCollection) IsValid() bool {
+	return g.Valid
+}
+
+func (g GeomCollection) GetValue() interface{} {
+	return g.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g GeomCollection) IsZero() bool {
+	return !g.Present
+}
+
+func (g GeomCollection) Ptr() *orb.Collection {
+	if g.Valid {
+		return &g.Data
 	}
-	g.Point = geojson.Point(p)
 	return nil
 }
 
-type GeomPolygon struct {
-	geojson.Polygon
+// String returns the geometry collection as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;GEOMETRYCOLLECTION(POINT(106.8 -6.2),LINESTRING(106.8 -6.2,106.9 -6.3))`.
+// It returns empty string if the value is not valid.
+func (g GeomCollection) String() string {
+	return g.fields().text()
 }
 
 var (
-	_ sql.Scanner      = (*GeomPolygon)(nil)
-	_ json.Unmarshaler = (*GeomPolygon)(nil)
-	_ bson.Unmarshaler = (*GeomPolygon)(nil)
+	_ driver.Valuer                    = (*GeomCollection)(nil)
+	_ sql.Scanner                      = (*GeomCollection)(nil)
+	_ json.Marshaler                   = (*GeomCollection)(nil)
+	_ json.Unmarshaler                 = (*GeomCollection)(nil)
+	_ encoding.TextMarshaler           = (*GeomCollection)(nil)
+	_ encoding.TextUnmarshaler         = (*GeomCollection)(nil)
+	_ bson.ValueMarshaler              = (*GeomCollection)(nil)
+	_ bson.ValueUnmarshaler            = (*GeomCollection)(nil)
+	_ bson.Marshaler                   = (*GeomCollection)(nil)
+	_ bson.Unmarshaler                 = (*GeomCollection)(nil)
+	_ schema.QueryAppender             = (*GeomCollection)(nil)
+	_ pgtype.BytesScanner              = (*GeomCollection)(nil)
+	_ pgtype.BytesValuer               = (*GeomCollection)(nil)
+	_ gormschema.GormDataTypeInterface = (*GeomCollection)(nil)
+	_ migrator.GormDataTypeInterface   = (*GeomCollection)(nil)
+	_ gorm.Valuer                      = (*GeomCollection)(nil)
 )
 
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *GeomCollection) fields() geometryFields[orb.Collection] {
+	return geometryFields[orb.Collection]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: &g.Data}
+}
+
 // Scan implements sql.Scanner interface
-func (g *GeomPolygon) Scan(input interface{}) error {
-	var data []byte
-	switch v := input.(type) {
-	case []byte:
-		data = v
-	case string:
-		data = []byte(v)
-	default:
-		return fmt.Errorf("invalid geometry type: get %v", v)
+//
+// EWKB, hex-EWKB, WKT and EWKT are accepted.
+func (g *GeomCollection) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// GeomCollection is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g GeomCollection) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g GeomCollection) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *GeomCollection) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g GeomCollection) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (GeomCollection) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (GeomCollection) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "GeometryCollection")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g GeomCollection) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// GeomCollection is encoded as GeoJSON geometry object.
+func (g GeomCollection) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *GeomCollection) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g GeomCollection) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *GeomCollection) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// GeomCollection is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g GeomCollection) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *GeomCollection) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// GeomCollection is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g GeomCollection) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *GeomCollection) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// Geometry represents any geometry that may be null or not
+// present in JSON at all.
+//
+// Data holds the concrete orb geometry, e.g. orb.Point or orb.LineString,
+// which can be inspected with a type switch.
+type Geometry struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid geometry
+	SRID    int  // SRID is the spatial reference ID of the geometry, DefaultSRID is written when it is 0
+	Data    orb.Geometry
+}
+
+func NewGeometry(data orb.Geometry, presentValid ...bool) Geometry {
+	d := Geometry{
+		Present: true,
+		Valid:   data != nil,
+		Data:    data,
 	}
-	p := orb.Polygon{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
-	if err != nil {
-		return err
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
 	}
-	g.Polygon = geojson.Polygon(p)
-	return nil
+	return d
+}
+
+func NewGeometryPtr(data orb.Geometry, presentValid ...bool) *Geometry {
+	d := NewGeometry(data, presentValid...)
+	return &d
+}
+
+func (g Geometry) IsPresent() bool {
+	return g.Present
+}
+
+func (g Geometry) IsValid() bool {
+	return g.Valid
+}
+
+func (g Geometry) GetValue() interface{} {
+	return g.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (g Geometry) IsZero() bool {
+	return !g.Present
+}
+
+// String returns the geometry as WKT, or EWKT if SRID is not 0, e.g. `SRID=4326;POINT(106.8 -6.2)` or `SRID=4326;LINESTRING(106.8 -6.2,106.9 -6.3)`.
+// It returns empty string if the value is not valid.
+func (g Geometry) String() string {
+	return g.fields().text()
+}
+
+var (
+	_ driver.Valuer                    = (*Geometry)(nil)
+	_ sql.Scanner                      = (*Geometry)(nil)
+	_ json.Marshaler                   = (*Geometry)(nil)
+	_ json.Unmarshaler                 = (*Geometry)(nil)
+	_ encoding.TextMarshaler           = (*Geometry)(nil)
+	_ encoding.TextUnmarshaler         = (*Geometry)(nil)
+	_ bson.ValueMarshaler              = (*Geometry)(nil)
+	_ bson.ValueUnmarshaler            = (*Geometry)(nil)
+	_ bson.Marshaler                   = (*Geometry)(nil)
+	_ bson.Unmarshaler                 = (*Geometry)(nil)
+	_ schema.QueryAppender             = (*Geometry)(nil)
+	_ pgtype.BytesScanner              = (*Geometry)(nil)
+	_ pgtype.BytesValuer               = (*Geometry)(nil)
+	_ gormschema.GormDataTypeInterface = (*Geometry)(nil)
+	_ migrator.GormDataTypeInterface   = (*Geometry)(nil)
+	_ gorm.Valuer                      = (*Geometry)(nil)
+)
+
+// fields returns the fields of g for the implementation shared by geometry types.
+func (g *Geometry) fields() geometryFields[orb.Geometry] {
+	return geometryFields[orb.Geometry]{present: &g.Present, valid: &g.Valid, srid: &g.SRID, data: &g.Data}
+}
+
+// Scan implements sql.Scanner interface
+//
+// Any EWKB, hex-EWKB, WKT or EWKT geometry is accepted.
+func (g *Geometry) Scan(value interface{}) error {
+	return g.fields().scan(value)
+}
+
+// Value implements driver.Valuer interface
+//
+// Geometry is written as EWKB with its SRID, or DefaultSRID if SRID is 0.
+func (g Geometry) Value() (driver.Value, error) {
+	return g.fields().value()
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (g Geometry) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return g.fields().appendQuery(gen, b)
+}
+
+// ScanBytes implements pgtype.BytesScanner interface, for PostGIS binary format.
+func (g *Geometry) ScanBytes(v []byte) error {
+	return g.Scan(v)
+}
+
+// BytesValue implements pgtype.BytesValuer interface, for PostGIS binary format.
+func (g Geometry) BytesValue() ([]byte, error) {
+	return g.fields().bytesValue()
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Geometry) GormDataType() string {
+	return "geometry"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Geometry) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return geometryDBDataType(db, "Geometry")
+}
+
+// GormValue implements gorm.Valuer interface.
+func (g Geometry) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	return g.fields().gormValue(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+//
+// Geometry is encoded as GeoJSON geometry object.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	return g.fields().marshalJSON()
 }
 
 // UnmarshalJSON implements json.Marshaler interface.
-func (g *GeomPolygon) UnmarshalJSON(data []byte) error {
-	p := orb.Polygon{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
-	if err != nil {
+//
+// Both GeoJSON geometry object and hex-EWKB string are accepted.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	return g.fields().unmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (g Geometry) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+//
+// Empty text is decoded as null.
+func (g *Geometry) UnmarshalText(text []byte) error {
+	return g.fields().unmarshalText(text)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// Geometry is encoded as GeoJSON geometry subdocument, as used by `2dsphere` index.
+func (g Geometry) MarshalBSONValue() (byte, []byte, error) {
+	return g.fields().marshalBSONValue()
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// Both GeoJSON geometry subdocument and EWKB binary are accepted.
+func (g *Geometry) UnmarshalBSONValue(t byte, data []byte) error {
+	return g.fields().unmarshalBSONValue(t, data)
+}
+
+// MarshalBSON implements bson.Marshaler interface.
+//
+// Geometry is encoded as GeoJSON geometry document. Null value has no document form, so it is rejected.
+func (g Geometry) MarshalBSON() ([]byte, error) {
+	return marshalGeometryBSONDocument(g.MarshalBSONValue())
+}
+
+// UnmarshalBSON implements bson.Unmarshaler interface.
+//
+// data is decoded as GeoJSON geometry document.
+func (g *Geometry) UnmarshalBSON(data []byte) error {
+	return g.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data)
+}
+
+// geometryFields points to the fields of a geometry type, whose geometry is of type T.
+// It implements the methods shared by the geometry types, which only wrap it.
+type geometryFields[T orb.Geometry] struct {
+	present *bool
+	valid   *bool
+	srid    *int
+	data    *T
+}
+
+// isValid reports whether the value is valid and holds a geometry.
+func (f geometryFields[T]) isValid() bool {
+	return *f.valid && any(*f.data) != nil
+}
+
+// reset marks the value as present and null, before it is decoded.
+func (f geometryFields[T]) reset() {
+	*f.present = true
+	*f.valid = false
+}
+
+// set stores geom and srid returned by a geometry decoder. The value stays null if ok is false or err is not nil.
+func (f geometryFields[T]) set(geom T, srid int, ok bool, err error) error {
+	if err != nil || !ok {
 		return err
 	}
-	g.Polygon = geojson.Polygon(p)
+	*f.data = geom
+	*f.srid = srid
+	*f.valid = true
 	return nil
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (g *GeomPolygon) UnmarshalBSON(data []byte) error {
-	p := orb.Polygon{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
-	if err != nil {
-		return err
+func (f geometryFields[T]) text() string {
+	if !f.isValid() {
+		return ""
 	}
-	g.Polygon = geojson.Polygon(p)
-	return nil
+	return formatGeometryText(*f.data, *f.srid)
 }
 
-type GeomMultiPolygon struct {
-	geojson.MultiPolygon
+func (f geometryFields[T]) scan(value interface{}) error {
+	f.reset()
+	return f.set(scanGeometry[T](value))
 }
 
-var (
-	_ sql.Scanner      = (*GeomMultiPolygon)(nil)
-	_ json.Unmarshaler = (*GeomMultiPolygon)(nil)
-	_ bson.Unmarshaler = (*GeomMultiPolygon)(nil)
-)
+func (f geometryFields[T]) value() (driver.Value, error) {
+	if !f.isValid() {
+		return nil, nil
+	}
+	return geometryValue(*f.data, *f.srid)
+}
 
-// Scan implements sql.Scanner interface
-func (g *GeomMultiPolygon) Scan(input interface{}) error {
+func (f geometryFields[T]) appendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	if !f.isValid() {
+		return dialect.AppendNull(b), nil
+	}
+	return appendGeometryQuery(gen, b, *f.data, *f.srid)
+}
+
+func (f geometryFields[T]) bytesValue() ([]byte, error) {
+	if !f.isValid() {
+		return nil, nil
+	}
+	return ewkb.Marshal(*f.data, geometrySRID(*f.srid))
+}
+
+func (f geometryFields[T]) gormValue(db *gorm.DB) clause.Expr {
+	if !f.isValid() {
+		return gormNull
+	}
+	return geometryGormValue(db, *f.data, *f.srid)
+}
+
+func (f geometryFields[T]) marshalJSON() ([]byte, error) {
+	if !*f.present || !f.isValid() {
+		return []byte(`null`), nil
+	}
+	return marshalGeometryJSON(*f.data)
+}
+
+func (f geometryFields[T]) unmarshalJSON(data []byte) error {
+	f.reset()
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	geom, srid, err := unmarshalGeometryJSON[T](data)
+	return f.set(geom, srid, true, err)
+}
+
+func (f geometryFields[T]) unmarshalText(text []byte) error {
+	if len(text) == 0 {
+		f.reset()
+		return nil
+	}
+	return f.scan(string(text))
+}
+
+func (f geometryFields[T]) marshalBSONValue() (byte, []byte, error) {
+	if !*f.present || !f.isValid() {
+		return byte(bson.TypeNull), nil, nil
+	}
+	return marshalGeometryBSON(*f.data)
+}
+
+func (f geometryFields[T]) unmarshalBSONValue(t byte, data []byte) error {
+	f.reset()
+	return f.set(unmarshalGeometryBSON[T](t, data))
+}
+
+// scanGeometry scans EWKB, hex-EWKB, WKT or EWKT value into geometry of type T.
+// MySQL geometry, which is WKB prefixed with 4 bytes SRID, is accepted too.
+// ok is false if value is nil.
+func scanGeometry[T orb.Geometry](value interface{}) (geom T, srid int, ok bool, err error) {
 	var data []byte
-	switch v := input.(type) {
+	switch v := value.(type) {
+	case nil:
+		return geom, 0, false, nil
 	case []byte:
 		data = v
 	case string:
 		data = []byte(v)
 	default:
-		return fmt.Errorf("invalid geometry type: get %v", v)
+		return geom, 0, false, fmt.Errorf("invalid geometry type: get %v", v)
+	}
+
+	// Binary is decoded first, as the SRID prefix of MySQL may start with a letter, e.g. the first byte of 32609 is `a`.
+	gs := ewkb.Scanner(nil)
+	if err = gs.Scan(data); err != nil && len(data) > 4 {
+		if ps := ewkb.ScannerPrefixSRID(nil); ps.Scan(data) == nil {
+			gs, err = ps, nil
+		}
+	}
+	if err != nil && isGeometryText(data) {
+		geom, srid, err = parseGeometryText[T](string(data))
+		return geom, srid, err == nil, err
+	}
+	if err != nil || !gs.Valid {
+		return geom, 0, false, err
+	}
+	geom, err = geometryAs[T](gs.Geometry)
+	return geom, gs.SRID, err == nil, err
+}
+
+// geometrySRID returns srid, or DefaultSRID if srid is 0.
+func geometrySRID(srid int) int {
+	if srid == 0 {
+		return DefaultSRID
 	}
-	p := orb.MultiPolygon{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
+	return srid
+}
+
+// geometryValue encodes g as EWKB with srid.
+func geometryValue(g orb.Geometry, srid int) (driver.Value, error) {
+	return ewkb.Marshal(g, geometrySRID(srid))
+}
+
+// appendGeometryQuery appends `ST_GeomFromEWKB(<ewkb>)` of g to b.
+func appendGeometryQuery(gen schema.QueryGen, b []byte, g orb.Geometry, srid int) ([]byte, error) {
+	data, err := ewkb.Marshal(g, geometrySRID(srid))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	g.MultiPolygon = geojson.MultiPolygon(p)
-	return nil
+	b = append(b, "ST_GeomFromEWKB("...)
+	b = gen.Dialect().AppendBytes(b, data)
+	return append(b, ')'), nil
 }
 
-// UnmarshalJSON implements json.Marshaler interface.
-func (g *GeomMultiPolygon) UnmarshalJSON(data []byte) error {
-	p := orb.MultiPolygon{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
+// geometryDBDataType returns the column type of geometry kind, e.g. `Point`, for the gorm dialect of db.
+func geometryDBDataType(db *gorm.DB, kind string) string {
+	return gormColumnType{
+		Postgres: fmt.Sprintf("geometry(%s,%d)", kind, DefaultSRID),
+		MySQL:    strings.ToUpper(kind),
+	}.of(db)
+}
+
+// geometryGormValue returns g with srid as gorm expression.
+//
+// PostGIS reads EWKB with ST_GeomFromEWKB, MySQL reads WKT with ST_GeomFromText,
+// and other databases get EWKB as is.
+func geometryGormValue(db *gorm.DB, g orb.Geometry, srid int) clause.Expr {
+	srid = geometrySRID(srid)
+	switch db.Dialector.Name() {
+	case gormMySQL:
+		if gormMariaDB(db) {
+			return gorm.Expr("ST_GeomFromText(?, ?)", wkt.MarshalString(g), srid)
+		}
+		// MySQL reads geographic SRID, e.g. 4326, in latitude-longitude order by default
+		return gorm.Expr("ST_GeomFromText(?, ?, 'axis-order=long-lat')", wkt.MarshalString(g), srid)
+	}
+
+	data, err := ewkb.Marshal(g, srid)
 	if err != nil {
-		return err
+		_ = db.AddError(err)
+		return gormNull
 	}
-	g.MultiPolygon = geojson.MultiPolygon(p)
-	return nil
+	if db.Dialector.Name() == gormPostgres {
+		// EWKB is nested, as gorm expands slice argument that follows a parenthesis
+		return gorm.Expr("ST_GeomFromEWKB(?)", gorm.Expr("?", data))
+	}
+	return gorm.Expr("?", data)
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (g *GeomMultiPolygon) UnmarshalBSON(data []byte) error {
-	p := orb.MultiPolygon{}
-	gs := ewkb.Scanner(&p)
-	err := gs.Scan(data)
+// isGeometryText reports whether data is WKT or EWKT, rather than EWKB or hex-EWKB.
+func isGeometryText(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return false
+	}
+	c := data[0] | 0x20 // lower case
+	return c >= 'a' && c <= 'z'
+}
+
+// parseGeometryText parses WKT or EWKT string s, e.g. `SRID=4326;POINT(106.8 -6.2)`, into geometry of type T.
+func parseGeometryText[T orb.Geometry](s string) (T, int, error) {
+	var (
+		zero T
+		srid int
+	)
+	s = strings.TrimSpace(s)
+	if len(s) > 5 && strings.EqualFold(s[:5], "SRID=") {
+		i := strings.IndexByte(s, ';')
+		if i < 0 {
+			return zero, 0, fmt.Errorf("nullable: invalid EWKT geometry %q", s)
+		}
+		n, err := strconv.Atoi(s[5:i])
+		if err != nil {
+			return zero, 0, fmt.Errorf("nullable: invalid EWKT SRID %q", s[5:i])
+		}
+		srid, s = n, s[i+1:]
+	}
+
+	geom, err := wkt.Unmarshal(s)
 	if err != nil {
-		return err
+		return zero, 0, err
 	}
-	g.MultiPolygon = geojson.MultiPolygon(p)
-	return nil
+	g, err := geometryAs[T](geom)
+	return g, srid, err
+}
+
+// formatGeometryText formats g as WKT, or EWKT if srid is not 0.
+func formatGeometryText(g orb.Geometry, srid int) string {
+	if srid == 0 {
+		return wkt.MarshalString(g)
+	}
+	return "SRID=" + strconv.Itoa(srid) + ";" + wkt.MarshalString(g)
+}
+
+// marshalGeometryJSON encodes g as GeoJSON geometry object.
+func marshalGeometryJSON(g orb.Geometry) ([]byte, error) {
+	return json.Marshal(geojson.NewGeometry(g))
+}
+
+// marshalGeometryBSON encodes g as GeoJSON geometry subdocument.
+func marshalGeometryBSON(g orb.Geometry) (byte, []byte, error) {
+	return geojson.NewGeometry(g).MarshalBSONValue()
+}
+
+// marshalGeometryBSONDocument returns the GeoJSON geometry document of BSON value from MarshalBSONValue.
+// It overrides bson.Marshaler of the embedded geojson type, which ignores Present and Valid.
+func marshalGeometryBSONDocument(t byte, data []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if bson.Type(t) != bson.TypeEmbeddedDocument {
+		return nil, errors.New("nullable: cannot marshal null geometry as BSON document")
+	}
+	return data, nil
+}
+
+// unmarshalGeometryBSON decodes GeoJSON geometry subdocument, EWKB binary or (E)WKT string BSON value
+// into geometry of type T. ok is false if the value is null.
+func unmarshalGeometryBSON[T orb.Geometry](t byte, data []byte) (geom T, srid int, ok bool, err error) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return geom, 0, false, nil
+	case bson.TypeEmbeddedDocument:
+		g := &geojson.Geometry{}
+		if err = g.UnmarshalBSON(raw.Document()); err != nil {
+			return geom, 0, false, err
+		}
+		geom, err = geometryAs[T](g.Geometry())
+		return geom, 0, err == nil, err
+	case bson.TypeBinary:
+		_, b := raw.Binary()
+		return scanGeometry[T](b)
+	case bson.TypeString:
+		return scanGeometry[T](raw.StringValue())
+	default:
+		return geom, 0, false, fmt.Errorf("nullable: unsupported bson type %s for geometry", raw.Type)
+	}
+}
+
+// unmarshalGeometryJSON decodes GeoJSON geometry object or hex-EWKB string data,
+// and validates that the geometry is of type T.
+// The SRID is only known from hex-EWKB, it is 0 for GeoJSON.
+func unmarshalGeometryJSON[T orb.Geometry](data []byte) (T, int, error) {
+	var (
+		zero T
+		geom orb.Geometry
+		srid int
+	)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return zero, 0, err
+		}
+		b, err := hex.DecodeString(strings.TrimPrefix(s, `\x`))
+		if err != nil {
+			return zero, 0, fmt.Errorf("nullable: invalid hex-EWKB geometry: %w", err)
+		}
+		if geom, srid, err = ewkb.Unmarshal(b); err != nil {
+			return zero, 0, err
+		}
+	} else {
+		g, err := geojson.UnmarshalGeometry(data)
+		if err != nil {
+			return zero, 0, err
+		}
+		geom = g.Geometry()
+	}
+
+	g, err := geometryAs[T](geom)
+	return g, srid, err
+}
+
+// geometryAs returns geom as T, or an error if geom is of other geometry type.
+func geometryAs[T orb.Geometry](geom orb.Geometry) (T, error) {
+	g, ok := geom.(T)
+	if !ok {
+		var zero T
+		if geom == nil {
+			return zero, errors.New("nullable: empty geometry")
+		}
+		return zero, fmt.Errorf("nullable: expected %s geometry, got %s", zero.GeoJSONType(), geom.GeoJSONType())
+	}
+	return g, nil
 }
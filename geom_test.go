@@ -0,0 +1,582 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"encoding/json"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/ewkb"
+	"github.com/paulmach/orb/encoding/wkb"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/schema"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	testPoint   = orb.Point{106.8, -6.2}
+	testPolygon = orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}
+)
+
+type geomPointJsonTest struct {
+	Value GeomPoint `json:"value,omitzero"`
+}
+
+func TestGeomPoint_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   geomPointJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   geomPointJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name:   "null value",
+			data:   geomPointJsonTest{Value: NewGeomPoint(orb.Point{}, true, false)},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name:   "valid value",
+			data:   geomPointJsonTest{Value: NewGeomPoint(testPoint)},
+			expect: bytes.NewBufferString(`{"value":{"type":"Point","coordinates":[106.8,-6.2]}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestGeomPoint_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		buf     *bytes.Buffer
+		expect  orb.Point
+		null    bool
+		invalid bool
+	}{
+		{
+			name: "null value",
+			buf:  bytes.NewBufferString(`null`),
+			null: true,
+		},
+		{
+			name:   "geojson value",
+			buf:    bytes.NewBufferString(`{"type":"Point","coordinates":[106.8,-6.2]}`),
+			expect: testPoint,
+		},
+		{
+			name:   "hex-ewkb value",
+			buf:    bytes.NewBufferString(`"` + ewkb.MustMarshalToHex(testPoint, 4326) + `"`),
+			expect: testPoint,
+		},
+		{
+			name:    "mismatched geometry type",
+			buf:     bytes.NewBufferString(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`),
+			invalid: true,
+		},
+		{
+			name:    "invalid hex value",
+			buf:     bytes.NewBufferString(`"not hex"`),
+			invalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got GeomPoint
+			err := json.Unmarshal(tt.buf.Bytes(), &got)
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			if !got.Present || got.Valid == tt.null {
+				t.Fatalf("expected present %t and valid %t, got %t and %t", true, !tt.null, got.Present, got.Valid)
+			}
+			if !tt.null && !orb.Equal(orb.Point(got.Point), tt.expect) {
+				t.Errorf("expected value to be %v got %v", tt.expect, got.Point)
+			}
+		})
+	}
+}
+
+func TestGeomPolygon_JSON(t *testing.T) {
+	byt, err := json.Marshal(NewGeomPolygon(testPolygon))
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+
+	var got GeomPolygon
+	if err = json.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !orb.Equal(orb.Polygon(got.Polygon), testPolygon) {
+		t.Errorf("expected value to be %v got %v", testPolygon, got.Polygon)
+	}
+
+	var multi GeomMultiPolygon
+	if err = json.Unmarshal(byt, &multi); err == nil {
+		t.Errorf("expected error decoding Polygon into GeomMultiPolygon, got nil")
+	}
+}
+
+func TestGeomPoint_Scan(t *testing.T) {
+	var g GeomPoint
+	if err := g.Scan(nil); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !g.Present || g.Valid {
+		t.Errorf("expected present and not valid value, got %+v", g)
+	}
+
+	value, err := NewGeomPoint(testPoint).Value()
+	if err != nil {
+		t.Fatalf("unexpected value error: %s", err)
+	}
+	if err = g.Scan(value); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if !g.Valid || !orb.Equal(orb.Point(g.Point), testPoint) {
+		t.Errorf("expected value to be %v got %v", testPoint, g.Point)
+	}
+
+	var polygon GeomPolygon
+	if err = polygon.Scan(value); err == nil {
+		t.Errorf("expected error scanning Point into GeomPolygon, got nil")
+	}
+
+	// MySQL geometry is WKB prefixed with 4 bytes SRID.
+	// The first byte of 32609 and 32577 is a letter, `a` and `A`, which must not be read as WKT.
+	data, err := wkb.Marshal(testPoint)
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	for _, srid := range []uint32{4326, 32609, 32577} {
+		if err = g.Scan(append(binary.LittleEndian.AppendUint32(nil, srid), data...)); err != nil {
+			t.Fatalf("unexpected scan error of SRID %d: %s", srid, err)
+		}
+		if !g.Valid || g.SRID != int(srid) || !orb.Equal(orb.Point(g.Point), testPoint) {
+			t.Errorf("expected value to be %v with SRID %d got %+v", testPoint, srid, g)
+		}
+	}
+}
+
+func TestGeomPoint_Value(t *testing.T) {
+	value, err := NewGeomPoint(orb.Point{}, true, false).Value()
+	if err != nil {
+		t.Fatalf("unexpected value error: %s", err)
+	}
+	if value != nil {
+		t.Errorf("expected nil value, got %v", value)
+	}
+
+	value, err = NewGeomPoint(testPoint).Value()
+	if err != nil {
+		t.Fatalf("unexpected value error: %s", err)
+	}
+	expect := ewkb.MustMarshal(testPoint, DefaultSRID)
+	if !bytes.Equal(value.([]byte), expect) {
+		t.Errorf("expected value to be %x got %x", expect, value)
+	}
+}
+
+func TestGeomPoint_AppendQuery(t *testing.T) {
+	gen := schema.NewQueryGen(pgdialect.New())
+
+	b, err := NewGeomPoint(orb.Point{}, true, false).AppendQuery(gen, nil)
+	if err != nil {
+		t.Fatalf("unexpected append error: %s", err)
+	}
+	if string(b) != "NULL" {
+		t.Errorf("expected value to be NULL got %s", b)
+	}
+
+	b, err = NewGeomPoint(testPoint).AppendQuery(gen, nil)
+	if err != nil {
+		t.Fatalf("unexpected append error: %s", err)
+	}
+	expect := `ST_GeomFromEWKB('\x` + ewkb.MustMarshalToHex(testPoint, DefaultSRID) + `')`
+	if string(b) != expect {
+		t.Errorf("expected value to be %s got %s", expect, b)
+	}
+}
+
+func TestGeometry_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		geom orb.Geometry
+		dst  interface {
+			Scan(value interface{}) error
+			GetValue() interface{}
+		}
+	}{
+		{name: "multi point", geom: orb.MultiPoint{{0, 0}, {1, 1}}, dst: &GeomMultiPoint{}},
+		{name: "line string", geom: orb.LineString{{0, 0}, {1, 1}}, dst: &GeomLineString{}},
+		{name: "multi line string", geom: orb.MultiLineString{{{0, 0}, {1, 1}}}, dst: &GeomMultiLineString{}},
+		{name: "collection", geom: orb.Collection{testPoint, orb.LineString{{0, 0}, {1, 1}}}, dst: &GeomCollection{}},
+		{name: "any geometry", geom: orb.LineString{{0, 0}, {1, 1}}, dst: &Geometry{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.dst.Scan(ewkb.MustMarshal(tt.geom, DefaultSRID)); err != nil {
+				t.Fatalf("unexpected scan error: %s", err)
+			}
+
+			got := tt.dst.GetValue().(orb.Geometry)
+			if !orb.Equal(got, tt.geom) {
+				t.Errorf("expected value to be %v got %v", tt.geom, got)
+			}
+		})
+	}
+}
+
+func TestGeometry_JSON(t *testing.T) {
+	data := NewGeometry(orb.Collection{testPoint, testPolygon})
+	byt, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+
+	var got Geometry
+	if err = json.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !got.Valid || !orb.Equal(got.Data, data.Data) {
+		t.Errorf("expected value to be %v got %v", data.Data, got.Data)
+	}
+
+	var collection GeomCollection
+	if err = json.Unmarshal(byt, &collection); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !orb.Equal(collection.Data, data.Data) {
+		t.Errorf("expected value to be %v got %v", data.Data, collection.Data)
+	}
+}
+
+func TestGeomPoint_SRID(t *testing.T) {
+	var g GeomPoint
+	if err := g.Scan(ewkb.MustMarshal(testPoint, 3857)); err != nil {
+		t.Fatalf("unexpected scan error: %s", err)
+	}
+	if g.SRID != 3857 {
+		t.Errorf("expected SRID to be %d got %d", 3857, g.SRID)
+	}
+
+	value, err := g.Value()
+	if err != nil {
+		t.Fatalf("unexpected value error: %s", err)
+	}
+	if _, srid, _ := ewkb.Unmarshal(value.([]byte)); srid != 3857 {
+		t.Errorf("expected SRID to be %d got %d", 3857, srid)
+	}
+
+	value, err = NewGeomPoint(testPoint).Value()
+	if err != nil {
+		t.Fatalf("unexpected value error: %s", err)
+	}
+	if _, srid, _ := ewkb.Unmarshal(value.([]byte)); srid != DefaultSRID {
+		t.Errorf("expected SRID to be %d got %d", DefaultSRID, srid)
+	}
+}
+
+func TestGeometry_Text(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		expect  orb.Geometry
+		srid    int
+		invalid bool
+	}{
+		{
+			name:   "wkt value",
+			text:   "POINT(106.8 -6.2)",
+			expect: testPoint,
+		},
+		{
+			name:   "ewkt value",
+			text:   "SRID=4326;LINESTRING(0 0,1 1)",
+			expect: orb.LineString{{0, 0}, {1, 1}},
+			srid:   4326,
+		},
+		{
+			name:    "invalid srid",
+			text:    "SRID=abc;POINT(1 2)",
+			invalid: true,
+		},
+		{
+			name:    "invalid wkt",
+			text:    "POINT(1",
+			invalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Geometry
+			err := got.Scan(tt.text)
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected scan error: %s", err)
+			}
+
+			if !orb.Equal(got.Data, tt.expect) || got.SRID != tt.srid {
+				t.Errorf("expected value to be %v (SRID %d) got %v (SRID %d)", tt.expect, tt.srid, got.Data, got.SRID)
+			}
+			if got.String() != tt.text {
+				t.Errorf("expected value to be %s got %s", tt.text, got.String())
+			}
+		})
+	}
+}
+
+func TestGeom_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface {
+			encoding.TextUnmarshaler
+			fmt.Stringer
+		}
+		text string
+	}{
+		{name: "point", value: &GeomPoint{}, text: "SRID=4326;POINT(106.8 -6.2)"},
+		{name: "multi point", value: &GeomMultiPoint{}, text: "SRID=4326;MULTIPOINT((106.8 -6.2),(106.9 -6.3))"},
+		{name: "line string", value: &GeomLineString{}, text: "SRID=4326;LINESTRING(106.8 -6.2,106.9 -6.3)"},
+		{name: "multi line string", value: &GeomMultiLineString{}, text: "SRID=4326;MULTILINESTRING((106.8 -6.2,106.9 -6.3))"},
+		{name: "polygon", value: &GeomPolygon{}, text: "SRID=4326;POLYGON((106.8 -6.2,106.9 -6.3,106.8 -6.3,106.8 -6.2))"},
+		{name: "multi polygon", value: &GeomMultiPolygon{}, text: "SRID=4326;MULTIPOLYGON(((106.8 -6.2,106.9 -6.3,106.8 -6.3,106.8 -6.2)))"},
+		{name: "collection", value: &GeomCollection{}, text: "SRID=4326;GEOMETRYCOLLECTION(POINT(106.8 -6.2),LINESTRING(106.8 -6.2,106.9 -6.3))"},
+		{name: "geometry", value: &Geometry{}, text: "SRID=4326;LINESTRING(106.8 -6.2,106.9 -6.3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.value.UnmarshalText([]byte(tt.text)); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if got := tt.value.String(); got != tt.text {
+				t.Errorf("expected value to be %s got %s", tt.text, got)
+			}
+		})
+	}
+}
+
+func TestGeomPoint_MarshalText(t *testing.T) {
+	g := NewGeomPoint(testPoint)
+	g.SRID = 4326
+
+	text, err := g.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	expect := "SRID=4326;POINT(106.8 -6.2)"
+	if string(text) != expect {
+		t.Errorf("expected value to be %s got %s", expect, text)
+	}
+
+	var got GeomPoint
+	if err = got.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !got.Valid || got.SRID != 4326 || !orb.Equal(orb.Point(got.Point), testPoint) {
+		t.Errorf("expected value to be %v got %v", g, got)
+	}
+
+	if err = got.UnmarshalText(nil); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !got.Present || got.Valid {
+		t.Errorf("expected present and not valid value, got %+v", got)
+	}
+}
+
+type geomBsonTest struct {
+	Point      GeomPoint      `bson:"point,omitempty"`
+	LineString GeomLineString `bson:"line_string,omitempty"`
+	Geometry   Geometry       `bson:"geometry,omitempty"`
+}
+
+func TestGeometry_BSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   geomBsonTest
+		expect bson.D
+	}{
+		{
+			name:   "undefined value",
+			data:   geomBsonTest{},
+			expect: bson.D{},
+		},
+		{
+			name: "null value",
+			data: geomBsonTest{
+				Point:      GeomPoint{Present: true},
+				LineString: GeomLineString{Present: true},
+				Geometry:   Geometry{Present: true},
+			},
+			expect: bson.D{
+				{Key: "point", Value: nil},
+				{Key: "line_string", Value: nil},
+				{Key: "geometry", Value: nil},
+			},
+		},
+		{
+			name: "valid value",
+			data: geomBsonTest{
+				Point:      NewGeomPoint(testPoint),
+				LineString: NewGeomLineString(orb.LineString{{0, 0}, {1, 1}}),
+				Geometry:   NewGeometry(orb.Collection{testPoint}),
+			},
+			expect: bson.D{
+				{Key: "point", Value: bson.D{
+					{Key: "type", Value: "Point"},
+					{Key: "coordinates", Value: bson.A{106.8, -6.2}},
+				}},
+				{Key: "line_string", Value: bson.D{
+					{Key: "type", Value: "LineString"},
+					{Key: "coordinates", Value: bson.A{bson.A{0.0, 0.0}, bson.A{1.0, 1.0}}},
+				}},
+				{Key: "geometry", Value: bson.D{
+					{Key: "type", Value: "GeometryCollection"},
+					{Key: "geometries", Value: bson.A{bson.D{
+						{Key: "type", Value: "Point"},
+						{Key: "coordinates", Value: bson.A{106.8, -6.2}},
+					}}},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byt, err := bson.Marshal(tt.data)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			var doc bson.D
+			if err = bson.Unmarshal(byt, &doc); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if !reflect.DeepEqual(doc, tt.expect) {
+				t.Errorf("expected value to be %v got %v", tt.expect, doc)
+			}
+
+			var got geomBsonTest
+			if err = bson.Unmarshal(byt, &got); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if got.Point.Present != tt.data.Point.Present || got.Point.Valid != tt.data.Point.Valid ||
+				!orb.Equal(orb.Point(got.Point.Point), orb.Point(tt.data.Point.Point)) {
+				t.Errorf("expected value to be %+v got %+v", tt.data.Point, got.Point)
+			}
+			if got.Geometry.Valid && !orb.Equal(got.Geometry.Data, tt.data.Geometry.Data) {
+				t.Errorf("expected value to be %v got %v", tt.data.Geometry.Data, got.Geometry.Data)
+			}
+		})
+	}
+}
+
+func TestGeomPoint_UnmarshalBSONValue(t *testing.T) {
+	_, data, err := bson.MarshalValue(bson.Binary{Data: ewkb.MustMarshal(testPoint, 3857)})
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+
+	var got GeomPoint
+	if err = got.UnmarshalBSONValue(byte(bson.TypeBinary), data); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !got.Valid || got.SRID != 3857 || !orb.Equal(orb.Point(got.Point), testPoint) {
+		t.Errorf("expected value to be %v got %+v", testPoint, got)
+	}
+
+	_, data, err = bson.MarshalValue(bson.D{{Key: "type", Value: "Polygon"}, {Key: "coordinates", Value: bson.A{}}})
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	if err = got.UnmarshalBSONValue(byte(bson.TypeEmbeddedDocument), data); err == nil {
+		t.Errorf("expected error decoding Polygon into GeomPoint, got nil")
+	}
+}
+
+func TestGeom_MarshalBSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   bson.Marshaler
+		expect orb.Geometry
+	}{
+		{name: "absent point", data: GeomPoint{}},
+		{name: "null point", data: NewGeomPoint(testPoint, true, false)},
+		{name: "absent multi point", data: GeomMultiPoint{}},
+		{name: "absent line string", data: GeomLineString{}},
+		{name: "absent multi line string", data: GeomMultiLineString{}},
+		{name: "absent polygon", data: GeomPolygon{}},
+		{name: "absent multi polygon", data: GeomMultiPolygon{}},
+		{name: "null collection", data: GeomCollection{Present: true}},
+		{name: "null geometry", data: Geometry{Present: true}},
+		{name: "point", data: NewGeomPoint(testPoint), expect: testPoint},
+		{name: "geometry", data: NewGeometry(testPoint), expect: testPoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := bson.Marshal(tt.data)
+			if tt.expect == nil {
+				if err == nil {
+					t.Errorf("expected error marshaling null geometry, got %s", bson.Raw(data))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			var got Geometry
+			if err = bson.Unmarshal(data, &got); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if !got.Valid || !orb.Equal(got.Data, tt.expect) {
+				t.Errorf("expected value to be %v got %+v", tt.expect, got)
+			}
+		})
+	}
+}
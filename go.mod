@@ -4,6 +4,8 @@ go 1.26.2
 
 require (
 	github.com/dromara/carbon/v2 v2.6.16
+	github.com/google/uuid v1.6.0
+	github.com/jackc/pgx/v5 v5.9.2
 	github.com/lib/pq v1.12.3
 	github.com/paulmach/orb v0.13.0
 	github.com/uptrace/bun v1.2.17
@@ -12,15 +14,21 @@ require (
 	go.mongodb.org/mongo-driver/v2 v2.6.0
 	go.portalnesia.com/utils v1.0.10
 	gopkg.in/guregu/null.v4 v4.0.0
+	gorm.io/driver/mysql v1.6.0
+	gorm.io/gorm v1.31.2
 )
 
 require (
+	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/aymerick/douceur v0.2.0 // indirect
-	github.com/google/uuid v1.6.0 // indirect
+	github.com/go-sql-driver/mysql v1.8.1 // indirect
 	github.com/gorilla/css v1.0.0 // indirect
 	github.com/gosimple/slug v1.12.0 // indirect
 	github.com/gosimple/unidecode v1.0.1 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/matoous/go-nanoid/v2 v2.0.0 // indirect
 	github.com/microcosm-cc/bluemonday v1.0.19 // indirect
 	github.com/oklog/ulid v1.3.1 // indirect
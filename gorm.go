@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormschema "gorm.io/gorm/schema"
+)
+
+// Name of gorm dialectors, as returned by gorm.Dialector.Name.
+const (
+	gormPostgres  = "postgres"
+	gormMySQL     = "mysql"
+	gormSQLite    = "sqlite"
+	gormSQLServer = "sqlserver"
+)
+
+// gormNull is the NULL expression returned by GormValue of not valid value.
+var gormNull = clause.Expr{SQL: "NULL"}
+
+// gormColumnType is the column type of each gorm dialect.
+// Empty type falls back to the gorm dialector, using the GormDataType of the field.
+type gormColumnType struct {
+	Postgres  string
+	MySQL     string
+	SQLite    string
+	SQLServer string
+}
+
+// of returns the column type of the dialect of db.
+func (t gormColumnType) of(db *gorm.DB) string {
+	switch db.Dialector.Name() {
+	case gormPostgres:
+		return t.Postgres
+	case gormMySQL:
+		return t.MySQL
+	case gormSQLite:
+		return t.SQLite
+	case gormSQLServer:
+		return t.SQLServer
+	}
+	return ""
+}
+
+// gormSizedDataType returns the column type of field from the gorm dialector,
+// with size if the field has no `size` tag.
+// Struct fields have no size by default, so the dialector would pick the smallest number type.
+func gormSizedDataType(db *gorm.DB, field *gormschema.Field, size int) string {
+	if field.Size == 0 {
+		f := *field
+		f.Size = size
+		field = &f
+	}
+	return db.Dialector.DataTypeOf(field)
+}
+
+// gormFloatDataType returns the column type of float field with size.
+// gorm postgres dialector writes float as `decimal`, so `real` or `double precision` is used instead.
+func gormFloatDataType(db *gorm.DB, field *gormschema.Field, size int) string {
+	if db.Dialector.Name() == gormPostgres && field.Precision == 0 {
+		if size <= 32 {
+			return "real"
+		}
+		return "double precision"
+	}
+	return gormSizedDataType(db, field, size)
+}
+
+// gormMariaDB reports whether db is connected to MariaDB with the gorm mysql dialector.
+func gormMariaDB(db *gorm.DB) bool {
+	v, ok := db.Dialector.(*mysql.Dialector)
+	return ok && strings.Contains(v.ServerVersion, "MariaDB")
+}
+
+// gormJSON returns JSON data as gorm expression.
+//
+// JSON is casted in MySQL, as MySQL does not convert string parameter into JSON.
+// JSON type of MariaDB is an alias of LONGTEXT, so it is not casted.
+func gormJSON(db *gorm.DB, data []byte, err error) clause.Expr {
+	if err != nil {
+		_ = db.AddError(err)
+		return gormNull
+	}
+	if db.Dialector.Name() == gormMySQL && !gormMariaDB(db) {
+		return gorm.Expr("CAST(? AS JSON)", string(data))
+	}
+	return gorm.Expr("?", string(data))
+}
+
+// gormValue returns the driver.Value of v as gorm expression.
+func gormValue(db *gorm.DB, v driver.Valuer) clause.Expr {
+	val, err := v.Value()
+	if err != nil {
+		_ = db.AddError(err)
+		return gormNull
+	}
+	if val == nil {
+		return gormNull
+	}
+	return gorm.Expr("?", val)
+}
+
+// GormUpdates returns the present columns of v, a struct (or pointer to struct) of nullable fields,
+// for gorm `Updates`, so only present fields are updated.
+//
+// Present but not valid fields are updated to NULL.
+// The column name is taken from `column` setting of `gorm` tag, or the snake_case of the field name.
+// Fields tagged with `gorm:"-"` are skipped.
+//
+//	updates, err := nullable.GormUpdates(req)
+//	db.Model(&user).Updates(updates)
+func GormUpdates(v any) (map[string]any, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("nullable: update value must be a struct")
+	}
+
+	updates := make(map[string]any)
+	for _, f := range nullableFields(rv, "") {
+		if !f.Nullable.IsPresent() {
+			continue
+		}
+		settings := gormschema.ParseTagSetting(f.Field.Tag.Get("gorm"), ";")
+		if _, skip := settings["-"]; skip {
+			continue
+		}
+
+		column := settings["COLUMN"]
+		if column == "" {
+			column = toSnakeCase(f.Name)
+		}
+		updates[column] = f.Value.Interface()
+	}
+	return updates, nil
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/paulmach/orb"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+)
+
+// gormTestDialector is a minimal dialector with the name of a real dialector, for dry run.
+type gormTestDialector struct {
+	name string
+}
+
+func (d gormTestDialector) Name() string {
+	return d.name
+}
+
+func (gormTestDialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (gormTestDialector) Migrator(*gorm.DB) gorm.Migrator {
+	return nil
+}
+
+func (gormTestDialector) DataTypeOf(field *gormschema.Field) string {
+	return fmt.Sprintf("%s(%d)", field.DataType, field.Size)
+}
+
+func (gormTestDialector) DefaultValueOf(*gormschema.Field) clause.Expression {
+	return clause.Expr{SQL: "DEFAULT"}
+}
+
+func (gormTestDialector) BindVarTo(w clause.Writer, stmt *gorm.Statement, _ interface{}) {
+	_, _ = w.WriteString("$" + strconv.Itoa(len(stmt.Vars)))
+}
+
+func (gormTestDialector) QuoteTo(w clause.Writer, s string) {
+	_, _ = w.WriteString(`"` + s + `"`)
+}
+
+func (gormTestDialector) Explain(sql string, _ ...interface{}) string {
+	return sql
+}
+
+func openGormTest(t *testing.T, dialector gorm.Dialector) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("unexpected open error: %s", err)
+	}
+	return db
+}
+
+func openGormMySQL(t *testing.T, version string) *gorm.DB {
+	return openGormTest(t, mysql.New(mysql.Config{ServerVersion: version, SkipInitializeWithVersion: true}))
+}
+
+type gormAddress struct {
+	City string `json:"city"`
+}
+
+type gormTestModel struct {
+	ID       int64 `gorm:"primaryKey"`
+	Name     String
+	Age      Int
+	Score    Float
+	Small    Int32
+	Price    Decimal
+	UserID   UUID
+	Birth    Date
+	Address  Type[gormAddress]
+	Tags     StringArray
+	Ids      IntArray
+	Location GeomPoint
+	Area     Geometry
+	Timeout  Duration
+	Nickname String `gorm:"column:nick"`
+	Ignored  String `gorm:"-"`
+}
+
+func TestGormDBDataType(t *testing.T) {
+	tests := []struct {
+		name   string
+		db     *gorm.DB
+		expect map[string]string
+	}{
+		{
+			name: "postgres",
+			db:   openGormTest(t, gormTestDialector{name: "postgres"}),
+			expect: map[string]string{
+				"name":     "string(0)",
+				"age":      "int(64)",
+				"score":    "double precision",
+				"small":    "int(32)",
+				"price":    "numeric",
+				"user_id":  "uuid",
+				"birth":    "date",
+				"address":  "jsonb",
+				"tags":     "text[]",
+				"ids":      "bigint[]",
+				"location": "geometry(Point,4326)",
+				"area":     "geometry(Geometry,4326)",
+				"timeout":  "interval",
+			},
+		},
+		{
+			name: "mysql",
+			db:   openGormMySQL(t, "8.0.36"),
+			expect: map[string]string{
+				"name":     "longtext",
+				"age":      "bigint",
+				"score":    "double",
+				"small":    "int",
+				"price":    "decimal(65,30)",
+				"user_id":  "char(36)",
+				"birth":    "date",
+				"address":  "JSON",
+				"tags":     "JSON",
+				"ids":      "JSON",
+				"location": "POINT",
+				"area":     "GEOMETRY",
+				"timeout":  "varchar(64)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := gormschema.Parse(&gormTestModel{}, &sync.Map{}, gormschema.NamingStrategy{})
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+			m := migrator.Migrator{Config: migrator.Config{DB: tt.db, Dialector: tt.db.Dialector}}
+			for column, expect := range tt.expect {
+				field := s.LookUpField(column)
+				if field == nil {
+					t.Fatalf("unknown column %s", column)
+				}
+				if got := m.DataTypeOf(field); got != expect {
+					t.Errorf("expected %s type to be %s got %s", column, expect, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGormUpdates(t *testing.T) {
+	updates, err := GormUpdates(&gormTestModel{
+		Name:     NewString("john"),
+		Age:      Int{Present: true},
+		Nickname: NewString("jo"),
+		Ignored:  NewString("ignored"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected updates error: %s", err)
+	}
+
+	expect := map[string]any{
+		"name": NewString("john"),
+		"age":  Int{Present: true},
+		"nick": NewString("jo"),
+	}
+	if !reflect.DeepEqual(updates, expect) {
+		t.Errorf("expected value to be %v got %v", expect, updates)
+	}
+
+	if _, err = GormUpdates("name"); err == nil {
+		t.Errorf("expected error for non struct value, got nil")
+	}
+}
+
+func TestGormValue(t *testing.T) {
+	model := gormTestModel{
+		Name:     NewString("john"),
+		Age:      Int{Present: true},
+		Address:  NewType(gormAddress{City: "Denpasar"}),
+		Tags:     NewStringArray([]string{"a", "b"}),
+		Location: NewGeomPoint(orb.Point{115.2, -8.6}),
+		Timeout:  NewDuration(90 * time.Minute),
+	}
+	ewkbPoint, _ := model.Location.Value()
+
+	tests := []struct {
+		name       string
+		db         *gorm.DB
+		expectSQL  string
+		expectVars []interface{}
+	}{
+		{
+			name:       "postgres",
+			db:         openGormTest(t, gormTestDialector{name: "postgres"}),
+			expectSQL:  `UPDATE "gorm_test_models" SET "address"=$1,"age"=$2,"location"=ST_GeomFromEWKB($3),"name"=$4,"tags"=$5,"timeout"=$6 WHERE "id" = $7`,
+			expectVars: []interface{}{`{"city":"Denpasar"}`, nil, ewkbPoint, "john", `{"a","b"}`, "PT1H30M", int64(1)},
+		},
+		{
+			name:       "mysql",
+			db:         openGormMySQL(t, "8.0.36"),
+			expectSQL:  "UPDATE `gorm_test_models` SET `address`=CAST(? AS JSON),`age`=?,`location`=ST_GeomFromText(?, ?, 'axis-order=long-lat'),`name`=?,`tags`=CAST(? AS JSON),`timeout`=? WHERE `id` = ?",
+			expectVars: []interface{}{`{"city":"Denpasar"}`, nil, "POINT(115.2 -8.6)", 4326, "john", `["a","b"]`, "PT1H30M", int64(1)},
+		},
+		{
+			name:       "mariadb",
+			db:         openGormMySQL(t, "10.11.6-MariaDB"),
+			expectSQL:  "UPDATE `gorm_test_models` SET `address`=?,`age`=?,`location`=ST_GeomFromText(?, ?),`name`=?,`tags`=?,`timeout`=? WHERE `id` = ?",
+			expectVars: []interface{}{`{"city":"Denpasar"}`, nil, "POINT(115.2 -8.6)", 4326, "john", `["a","b"]`, "PT1H30M", int64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := GormUpdates(model)
+			if err != nil {
+				t.Fatalf("unexpected updates error: %s", err)
+			}
+			stmt := tt.db.Model(&gormTestModel{ID: 1}).Updates(updates).Statement
+			if stmt.Error != nil {
+				t.Fatalf("unexpected query error: %s", stmt.Error)
+			}
+			if got := stmt.SQL.String(); got != tt.expectSQL {
+				t.Errorf("expected query to be %s got %s", tt.expectSQL, got)
+			}
+
+			vars := make([]interface{}, len(stmt.Vars))
+			for i, v := range stmt.Vars {
+				if valuer, ok := v.(driver.Valuer); ok {
+					v, _ = valuer.Value()
+				}
+				vars[i] = v
+			}
+			if !reflect.DeepEqual(vars, tt.expectVars) {
+				t.Errorf("expected vars to be %v got %v", tt.expectVars, vars)
+			}
+		})
+	}
+}
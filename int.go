@@ -14,8 +14,13 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 
 	"encoding/json"
 
@@ -64,6 +69,12 @@ func (d Int) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Int) IsZero() bool {
+	return !d.Present
+}
+
 func (d Int) Null() null.Int {
 	return null.NewInt(d.Data, d.Present && d.Valid)
 }
@@ -76,14 +87,19 @@ func (d Int) Ptr() *int64 {
 }
 
 var (
-	_ driver.Valuer       = (*Int)(nil)
-	_ sql.Scanner         = (*Int)(nil)
-	_ json.Marshaler      = (*Int)(nil)
-	_ json.Unmarshaler    = (*Int)(nil)
-	_ bson.Marshaler      = (*Int)(nil)
-	_ bson.Unmarshaler    = (*Int)(nil)
-	_ msgpack.Marshaler   = (*Int)(nil)
-	_ msgpack.Unmarshaler = (*Int)(nil)
+	_ driver.Valuer                    = (*Int)(nil)
+	_ sql.Scanner                      = (*Int)(nil)
+	_ json.Marshaler                   = (*Int)(nil)
+	_ json.Unmarshaler                 = (*Int)(nil)
+	_ bson.ValueMarshaler              = (*Int)(nil)
+	_ bson.ValueUnmarshaler            = (*Int)(nil)
+	_ msgpack.Marshaler                = (*Int)(nil)
+	_ msgpack.Unmarshaler              = (*Int)(nil)
+	_ pgtype.Int64Scanner              = (*Int)(nil)
+	_ pgtype.Int64Valuer               = (*Int)(nil)
+	_ schema.QueryAppender             = (*Int)(nil)
+	_ gormschema.GormDataTypeInterface = (*Int)(nil)
+	_ migrator.GormDataTypeInterface   = (*Int)(nil)
 )
 
 // Scan implements sql.Scanner interface
@@ -107,6 +123,34 @@ func (d Int) Value() (driver.Value, error) {
 	return d.Data, nil
 }
 
+// ScanInt64 implements pgtype.Int64Scanner interface.
+func (d *Int) ScanInt64(v pgtype.Int8) error {
+	d.Present = true
+	d.Valid = v.Valid
+	d.Data = v.Int64
+	return nil
+}
+
+// Int64Value implements pgtype.Int64Valuer interface.
+func (d Int) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: d.Data, Valid: d.Valid}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Int) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Int) GormDataType() string {
+	return string(gormschema.Int)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Int) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return gormSizedDataType(db, field, 64)
+}
+
 // MarshalJSON implements json.Marshaler interface.
 func (d Int) MarshalJSON() ([]byte, error) {
 	if !d.Present {
@@ -126,36 +170,34 @@ func (d *Int) UnmarshalJSON(data []byte) error {
 	}
 
 	if err := json.Unmarshal(data, &d.Data); err != nil {
-		return nil
+		return decodeError(data, "int64", err)
 	}
 
 	d.Valid = true
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d Int) MarshalBSON() (byt []byte, err error) {
-	var tmp *int
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d Int) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
 	}
-	_, byt, err = bson.MarshalValue(d.Data)
-	return byt, err
+	t, byt, err := bson.MarshalValue(d.Data)
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *Int) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Int) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
 		return nil
 	}
 
-	if err := bson.Unmarshal(data, &d.Data); err != nil {
-		return nil
+	if err := raw.Unmarshal(&d.Data); err != nil {
+		return decodeError(data, "int64", err)
 	}
 
 	d.Valid = true
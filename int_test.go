@@ -15,7 +15,7 @@ import (
 )
 
 type intJsonTest struct {
-	Value Int `json:"value"`
+	Value Int `json:"value,omitzero"`
 }
 
 func TestInt_MarshalJSON(t *testing.T) {
@@ -24,6 +24,11 @@ func TestInt_MarshalJSON(t *testing.T) {
 		data   intJsonTest
 		expect *bytes.Buffer
 	}{
+		{
+			name:   "undefined value",
+			data:   intJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
 		{
 			name: "null value",
 			data: intJsonTest{
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MongoNullMode controls how present but null fields are written by BuildMongoUpdate.
+type MongoNullMode int
+
+const (
+	MongoNullUnset MongoNullMode = iota // MongoNullUnset removes the field with `$unset`
+	MongoNullSet                        // MongoNullSet writes null to the field with `$set`
+)
+
+// BuildMongoUpdate builds Mongo update document from v, a struct (or pointer to struct) of nullable fields.
+//
+// Present and valid fields go to `$set`, present but null fields go to `$unset` or `$set` depending on mode,
+// and fields that are not present are skipped.
+// The field name is taken from `bson` tag, or the lowercase field name. Fields tagged with `-` are skipped.
+// When Type[D] holds a struct with nullable fields, its fields are written with dotted path, e.g. `address.city`.
+//
+// The result is empty if there is no present field, which is rejected by Mongo as update document.
+//
+//	update, err := nullable.BuildMongoUpdate(req, nullable.MongoNullUnset)
+//	collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, update)
+func BuildMongoUpdate(v any, mode MongoNullMode) (bson.D, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("nullable: update value must be a struct")
+	}
+
+	var set, unset bson.D
+	buildMongoUpdate(rv, "", mode, &set, &unset)
+
+	update := bson.D{}
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+	return update, nil
+}
+
+func buildMongoUpdate(v reflect.Value, prefix string, mode MongoNullMode, set, unset *bson.D) {
+	for _, f := range nullableFields(v, "bson") {
+		if !f.Nullable.IsPresent() {
+			continue
+		}
+
+		key := f.Tag
+		if key == "" {
+			key = strings.ToLower(f.Name)
+		}
+		key = prefix + key
+
+		if !f.Nullable.IsValid() {
+			if mode == MongoNullSet {
+				*set = append(*set, bson.E{Key: key, Value: nil})
+			} else {
+				*unset = append(*unset, bson.E{Key: key, Value: ""})
+			}
+			continue
+		}
+
+		val := reflect.ValueOf(f.Nullable.GetValue())
+		if val.Kind() == reflect.Struct && hasNullableFields(val.Type()) {
+			buildMongoUpdate(val, key+".", mode, set, unset)
+			continue
+		}
+		*set = append(*set, bson.E{Key: key, Value: f.Value.Interface()})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type mongoAddress struct {
+	City    String `bson:"city"`
+	Country String `bson:"country"`
+}
+
+type mongoUpdateTest struct {
+	Name    String             `bson:"name"`
+	Age     Int                `bson:"age"`
+	Email   String             `bson:"-"`
+	Address Type[mongoAddress] `bson:"address"`
+	Active  Bool
+}
+
+func TestBuildMongoUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   mongoUpdateTest
+		mode   MongoNullMode
+		expect bson.D
+	}{
+		{
+			name:   "undefined",
+			data:   mongoUpdateTest{},
+			expect: bson.D{},
+		},
+		{
+			name: "unset null",
+			data: mongoUpdateTest{
+				Name:   NewString("name"),
+				Age:    NewInt(0, true, false),
+				Email:  NewString("mail"),
+				Active: NewBool(true),
+			},
+			mode: MongoNullUnset,
+			expect: bson.D{
+				{Key: "$set", Value: bson.D{{Key: "name", Value: NewString("name")}, {Key: "active", Value: NewBool(true)}}},
+				{Key: "$unset", Value: bson.D{{Key: "age", Value: ""}}},
+			},
+		},
+		{
+			name: "set null",
+			data: mongoUpdateTest{
+				Age: NewInt(0, true, false),
+			},
+			mode: MongoNullSet,
+			expect: bson.D{
+				{Key: "$set", Value: bson.D{{Key: "age", Value: nil}}},
+			},
+		},
+		{
+			name: "nested value",
+			data: mongoUpdateTest{
+				Address: NewType(mongoAddress{City: NewString("Denpasar"), Country: NewString("", true, false)}),
+			},
+			expect: bson.D{
+				{Key: "$set", Value: bson.D{{Key: "address.city", Value: NewString("Denpasar")}}},
+				{Key: "$unset", Value: bson.D{{Key: "address.country", Value: ""}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildMongoUpdate(&tt.data, tt.mode)
+			if err != nil {
+				t.Fatalf("unexpected build error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("expected value to be %v got %v", tt.expect, got)
+			}
+		})
+	}
+}
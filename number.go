@@ -0,0 +1,573 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+)
+
+// Numeric is the constraint of the data of Number.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Number represents a sized integer or float that may be null or not
+// present in JSON at all.
+//
+// Value that does not fit into N is rejected with ErrNumberOverflow
+// when scanning or decoding.
+type Number[N Numeric] struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid number
+	Data    N
+}
+
+type (
+	Int8    = Number[int8]
+	Int16   = Number[int16]
+	Int32   = Number[int32]
+	Uint    = Number[uint]
+	Uint8   = Number[uint8]
+	Uint16  = Number[uint16]
+	Uint32  = Number[uint32]
+	Uint64  = Number[uint64]
+	Float32 = Number[float32]
+)
+
+func NewNumber[N Numeric](data N, presentValid ...bool) Number[N] {
+	d := Number[N]{
+		Present: true,
+		Valid:   true,
+		Data:    data,
+	}
+
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewNumberPtr[N Numeric](data N, presentValid ...bool) *Number[N] {
+	d := NewNumber(data, presentValid...)
+	return &d
+}
+
+func (d Number[N]) IsPresent() bool {
+	return d.Present
+}
+
+func (d Number[N]) IsValid() bool {
+	return d.Valid
+}
+
+func (d Number[N]) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Number[N]) IsZero() bool {
+	return !d.Present
+}
+
+func (d Number[N]) Ptr() *N {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+var (
+	_ driver.Valuer                    = (*Number[int32])(nil)
+	_ sql.Scanner                      = (*Number[int32])(nil)
+	_ json.Marshaler                   = (*Number[int32])(nil)
+	_ json.Unmarshaler                 = (*Number[int32])(nil)
+	_ bson.ValueMarshaler              = (*Number[int32])(nil)
+	_ bson.ValueUnmarshaler            = (*Number[int32])(nil)
+	_ msgpack.Marshaler                = (*Number[int32])(nil)
+	_ msgpack.Unmarshaler              = (*Number[int32])(nil)
+	_ pgtype.Int64Scanner              = (*Number[int32])(nil)
+	_ pgtype.Int64Valuer               = (*Number[int32])(nil)
+	_ pgtype.Float64Scanner            = (*Number[int32])(nil)
+	_ pgtype.Float64Valuer             = (*Number[int32])(nil)
+	_ pgtype.NumericScanner            = (*Number[int32])(nil)
+	_ pgtype.NumericValuer             = (*Number[int32])(nil)
+	_ schema.QueryAppender             = (*Number[int32])(nil)
+	_ gormschema.GormDataTypeInterface = (*Number[int32])(nil)
+	_ migrator.GormDataTypeInterface   = (*Number[int32])(nil)
+)
+
+// Scan implements sql.Scanner interface
+func (d *Number[N]) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	if value == nil {
+		return nil
+	}
+
+	n, err := toNumber[N](value)
+	if err != nil {
+		return err
+	}
+	d.Valid = true
+	d.Data = n
+	return nil
+}
+
+// Value implements driver.Valuer interface
+//
+// Unsigned value larger than math.MaxInt64 is written as decimal string.
+func (d Number[N]) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	switch numberKind[N]() {
+	case kindFloat:
+		return float64(d.Data), nil
+	case kindUint:
+		u := uint64(d.Data)
+		if u > math.MaxInt64 {
+			return strconv.FormatUint(u, 10), nil
+		}
+		return int64(u), nil
+	default:
+		return int64(d.Data), nil
+	}
+}
+
+// ScanInt64 implements pgtype.Int64Scanner interface.
+func (d *Number[N]) ScanInt64(v pgtype.Int8) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	n, err := numberFromInt[N](v.Int64)
+	if err != nil {
+		return err
+	}
+	d.Valid = true
+	d.Data = n
+	return nil
+}
+
+// Int64Value implements pgtype.Int64Valuer interface.
+//
+// Float with fraction and unsigned value larger than math.MaxInt64 are rejected.
+func (d Number[N]) Int64Value() (pgtype.Int8, error) {
+	if !d.Valid {
+		return pgtype.Int8{}, nil
+	}
+
+	var (
+		i   int64
+		err error
+	)
+	switch numberKind[N]() {
+	case kindFloat:
+		i, err = numberFromFloat[int64](float64(d.Data))
+	case kindUint:
+		i, err = numberFromUint[int64](uint64(d.Data))
+	default:
+		i = int64(d.Data)
+	}
+	return pgtype.Int8{Int64: i, Valid: err == nil}, err
+}
+
+// ScanFloat64 implements pgtype.Float64Scanner interface.
+func (d *Number[N]) ScanFloat64(v pgtype.Float8) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	n, err := numberFromFloat[N](v.Float64)
+	if err != nil {
+		return err
+	}
+	d.Valid = true
+	d.Data = n
+	return nil
+}
+
+// Float64Value implements pgtype.Float64Valuer interface.
+func (d Number[N]) Float64Value() (pgtype.Float8, error) {
+	return pgtype.Float8{Float64: float64(d.Data), Valid: d.Valid}, nil
+}
+
+// ScanNumeric implements pgtype.NumericScanner interface.
+func (d *Number[N]) ScanNumeric(v pgtype.Numeric) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	s, err := v.Value()
+	if err != nil {
+		return err
+	}
+	return d.Scan(s)
+}
+
+// NumericValue implements pgtype.NumericValuer interface.
+//
+// NumericValue is preferred over Float64Value by pgx, so unsigned value larger than math.MaxInt64 is written exactly.
+func (d Number[N]) NumericValue() (pgtype.Numeric, error) {
+	var n pgtype.Numeric
+	if !d.Valid {
+		return n, nil
+	}
+
+	var s string
+	switch numberKind[N]() {
+	case kindFloat:
+		f := float64(d.Data)
+		switch {
+		case math.IsInf(f, 1):
+			s = "Infinity"
+		case math.IsInf(f, -1):
+			s = "-Infinity"
+		default:
+			s = strconv.FormatFloat(f, 'f', -1, reflect.TypeFor[N]().Bits())
+		}
+	case kindUint:
+		s = strconv.FormatUint(uint64(d.Data), 10)
+	default:
+		s = strconv.FormatInt(int64(d.Data), 10)
+	}
+	err := n.Scan(s)
+	return n, err
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Number[N]) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Number[N]) GormDataType() string {
+	switch numberKind[N]() {
+	case kindFloat:
+		return string(gormschema.Float)
+	case kindUint:
+		return string(gormschema.Uint)
+	}
+	return string(gormschema.Int)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Number[N]) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	size := reflect.TypeFor[N]().Bits()
+	if numberKind[N]() == kindFloat {
+		return gormFloatDataType(db, field, size)
+	}
+	return gormSizedDataType(db, field, size)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d Number[N]) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Data)
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+func (d *Number[N]) UnmarshalJSON(data []byte) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err != nil {
+		return decodeError(data, reflect.TypeFor[N]().String(), err)
+	}
+
+	n, err := parseNumber[N](num.String())
+	if err != nil {
+		if errors.Is(err, ErrNumberOverflow) {
+			return err
+		}
+		return decodeError(data, reflect.TypeFor[N]().String(), err)
+	}
+
+	d.Data = n
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d Number[N]) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+
+	var (
+		t   bson.Type
+		byt []byte
+		err error
+	)
+	switch numberKind[N]() {
+	case kindFloat:
+		t, byt, err = bson.MarshalValue(float64(d.Data))
+	case kindUint:
+		u := uint64(d.Data)
+		if u > math.MaxInt64 {
+			return 0, nil, fmt.Errorf("%w: %d overflows bson int64", ErrNumberOverflow, u)
+		}
+		t, byt, err = bson.MarshalValue(int64(u))
+	default:
+		t, byt, err = bson.MarshalValue(d.Data)
+	}
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Number[N]) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		raw = bson.RawValue{Type: bson.Type(t), Value: data}
+		n   N
+		err error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil
+	case bson.TypeInt32, bson.TypeInt64:
+		n, err = numberFromInt[N](raw.AsInt64())
+	case bson.TypeDouble:
+		n, err = numberFromFloat[N](raw.Double())
+	case bson.TypeString:
+		n, err = parseNumber[N](raw.StringValue())
+	case bson.TypeDecimal128:
+		n, err = parseNumber[N](raw.Decimal128().String())
+	default:
+		err = fmt.Errorf("unsupported bson type %s", raw.Type)
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrNumberOverflow) {
+			return err
+		}
+		return decodeError(data, reflect.TypeFor[N]().String(), err)
+	}
+
+	d.Data = n
+	d.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d Number[N]) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(d.Data)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *Number[N]) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val interface{}
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	n, err := toNumber[N](val)
+	if err != nil {
+		return err
+	}
+	d.Valid = true
+	d.Data = n
+	return nil
+}
+
+func (Number[N]) FiberConverter(value string) reflect.Value {
+	n, err := parseNumber[N](value)
+	if err != nil {
+		a := NewNumber(n, true, false)
+		return reflect.ValueOf(a)
+	}
+	a := NewNumber(n, true, true)
+	return reflect.ValueOf(a)
+}
+
+const (
+	kindInt = iota
+	kindUint
+	kindFloat
+)
+
+func numberKind[N Numeric]() int {
+	switch reflect.TypeFor[N]().Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return kindUint
+	case reflect.Float32, reflect.Float64:
+		return kindFloat
+	default:
+		return kindInt
+	}
+}
+
+func overflowError[N Numeric](value interface{}) error {
+	return fmt.Errorf("%w: %v overflows %s", ErrNumberOverflow, value, reflect.TypeFor[N]())
+}
+
+// toNumber converts integer, float, string or []byte value into N.
+func toNumber[N Numeric](value interface{}) (N, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberFromInt[N](rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numberFromUint[N](rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return numberFromFloat[N](rv.Float())
+	case reflect.String:
+		return parseNumber[N](rv.String())
+	case reflect.Slice:
+		if b, ok := value.([]byte); ok {
+			return parseNumber[N](string(b))
+		}
+	}
+	return 0, fmt.Errorf("nullable: cannot convert %T to %s", value, reflect.TypeFor[N]())
+}
+
+func numberFromInt[N Numeric](i int64) (N, error) {
+	n := N(i)
+	switch numberKind[N]() {
+	case kindFloat:
+		return n, nil
+	case kindUint:
+		if i < 0 || uint64(n) != uint64(i) {
+			return 0, overflowError[N](i)
+		}
+	default:
+		if int64(n) != i {
+			return 0, overflowError[N](i)
+		}
+	}
+	return n, nil
+}
+
+func numberFromUint[N Numeric](u uint64) (N, error) {
+	n := N(u)
+	switch numberKind[N]() {
+	case kindFloat:
+		return n, nil
+	case kindUint:
+		if uint64(n) != u {
+			return 0, overflowError[N](u)
+		}
+	default:
+		if u > math.MaxInt64 {
+			return 0, overflowError[N](u)
+		}
+		return numberFromInt[N](int64(u))
+	}
+	return n, nil
+}
+
+func numberFromFloat[N Numeric](f float64) (N, error) {
+	switch numberKind[N]() {
+	case kindFloat:
+		if reflect.TypeFor[N]().Kind() == reflect.Float32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+			return 0, overflowError[N](f)
+		}
+		return N(f), nil
+	case kindUint:
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("nullable: %v is not an integer", f)
+		}
+		if f < 0 || f >= math.MaxUint64 {
+			return 0, overflowError[N](f)
+		}
+		return numberFromUint[N](uint64(f))
+	default:
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf("nullable: %v is not an integer", f)
+		}
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, overflowError[N](f)
+		}
+		return numberFromInt[N](int64(f))
+	}
+}
+
+// parseNumber parses decimal string s into N.
+// Integer in exponent form (e.g. 1e3) is accepted for integer types.
+func parseNumber[N Numeric](s string) (N, error) {
+	t := reflect.TypeFor[N]()
+	var err error
+	switch numberKind[N]() {
+	case kindFloat:
+		var f float64
+		if f, err = strconv.ParseFloat(s, t.Bits()); err == nil {
+			return N(f), nil
+		}
+	case kindUint:
+		var u uint64
+		if u, err = strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			return N(u), nil
+		}
+	default:
+		var i int64
+		if i, err = strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			return N(i), nil
+		}
+	}
+
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, overflowError[N](s)
+	}
+	if numberKind[N]() != kindFloat {
+		if f, ferr := strconv.ParseFloat(s, 64); ferr == nil {
+			return numberFromFloat[N](f)
+		}
+	}
+	return 0, err
+}
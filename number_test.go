@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"encoding/json"
+)
+
+type numberJsonTest struct {
+	Value Uint8 `json:"value,omitzero"`
+}
+
+func TestNumber_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   numberJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   numberJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: numberJsonTest{
+				Value: Uint8{
+					Present: true,
+					Valid:   false,
+				},
+			},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name: "valid value",
+			data: numberJsonTest{
+				Value: NewNumber[uint8](255),
+			},
+			expect: bytes.NewBufferString(`{"value":255}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestNumber_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		buf      *bytes.Buffer
+		expect   Uint8
+		overflow bool
+	}{
+		{
+			name: "null value",
+			buf:  bytes.NewBufferString(`{"value":null}`),
+			expect: Uint8{
+				Present: true,
+			},
+		},
+		{
+			name:   "valid value",
+			buf:    bytes.NewBufferString(`{"value":200}`),
+			expect: NewNumber[uint8](200),
+		},
+		{
+			name:   "empty",
+			buf:    bytes.NewBufferString(`null`),
+			expect: Uint8{},
+		},
+		{
+			name:     "overflow",
+			buf:      bytes.NewBufferString(`{"value":256}`),
+			overflow: true,
+		},
+		{
+			name:     "negative",
+			buf:      bytes.NewBufferString(`{"value":-1}`),
+			overflow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := struct {
+				Value Uint8 `json:"value"`
+			}{}
+
+			err := json.Unmarshal(tt.buf.Bytes(), &str)
+			if tt.overflow {
+				if !errors.Is(err, ErrNumberOverflow) {
+					t.Fatalf("expected overflow error got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			got := str.Value
+			if got != tt.expect {
+				t.Errorf("expected value to be %#v got %#v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestNumber_Scan(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expect   Int16
+		overflow bool
+	}{
+		{
+			name:   "null value",
+			value:  nil,
+			expect: Int16{Present: true},
+		},
+		{
+			name:   "int64",
+			value:  int64(-300),
+			expect: NewNumber[int16](-300),
+		},
+		{
+			name:   "bytes",
+			value:  []byte("1200"),
+			expect: NewNumber[int16](1200),
+		},
+		{
+			name:     "int64 overflow",
+			value:    int64(40000),
+			overflow: true,
+		},
+		{
+			name:     "bytes overflow",
+			value:    []byte("40000"),
+			overflow: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Int16
+			err := got.Scan(tt.value)
+			if tt.overflow {
+				if !errors.Is(err, ErrNumberOverflow) {
+					t.Fatalf("expected overflow error got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected scan error: %s", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected value to be %#v got %#v", tt.expect, got)
+			}
+		})
+	}
+}
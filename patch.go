@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyPatch applies patch to dst following JSON Merge Patch (RFC 7396) semantics.
+//
+// Patch must be a struct (or pointer to struct) made of nullable fields, and dst must be a pointer to struct.
+// Each nullable field of patch is matched to the field of dst with the same name, or with the same json tag name.
+//
+//   - Present and valid fields are copied to dst.
+//   - Present but not valid fields set the dst field to its zero value (nil for pointer).
+//   - Not present fields leave dst untouched.
+//
+// When the matched dst field has the same type as the patch field, the nullable value itself is copied.
+// When Type[D] holds a struct with nullable fields, it is applied recursively to the dst field.
+func ApplyPatch(dst, patch any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return errors.New("nullable: dst must be a non-nil pointer to struct")
+	}
+	pv := reflect.Indirect(reflect.ValueOf(patch))
+	if pv.Kind() != reflect.Struct {
+		return errors.New("nullable: patch must be a struct")
+	}
+	return applyPatch(dv.Elem(), pv)
+}
+
+func applyPatch(dst, patch reflect.Value) error {
+	for _, f := range nullableFields(patch, "json") {
+		if !f.Nullable.IsPresent() {
+			continue
+		}
+		target, ok := patchTarget(dst, f)
+		if !ok {
+			continue
+		}
+		if err := setPatchValue(target, f); err != nil {
+			return fmt.Errorf("nullable: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// patchTarget finds the field of dst that matches f by name or by json tag name.
+func patchTarget(dst reflect.Value, f nullableField) (reflect.Value, bool) {
+	if sf, ok := dst.Type().FieldByName(f.Name); ok && sf.IsExported() {
+		if target, err := dst.FieldByIndexErr(sf.Index); err == nil {
+			return target, true
+		}
+	}
+	if f.Tag == "" {
+		return reflect.Value{}, false
+	}
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if tag, skip := tagName(sf, "json"); !skip && tag == f.Tag {
+			return dst.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func setPatchValue(target reflect.Value, f nullableField) error {
+	if !target.CanSet() {
+		return errors.New("destination field can not be set")
+	}
+
+	if f.Value.Type().AssignableTo(target.Type()) {
+		target.Set(f.Value)
+		return nil
+	}
+
+	if !f.Nullable.IsValid() {
+		target.SetZero()
+		return nil
+	}
+
+	val := reflect.ValueOf(f.Nullable.GetValue())
+	if !val.IsValid() {
+		target.SetZero()
+		return nil
+	}
+
+	if target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
+
+	if val.Kind() == reflect.Struct && target.Kind() == reflect.Struct && hasNullableFields(val.Type()) {
+		return applyPatch(target, val)
+	}
+
+	switch {
+	case val.Type().AssignableTo(target.Type()):
+		target.Set(val)
+	case val.Kind() == target.Kind() && val.Type().ConvertibleTo(target.Type()):
+		target.Set(val.Convert(target.Type()))
+	default:
+		return fmt.Errorf("cannot assign %s to %s", val.Type(), target.Type())
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type patchAddress struct {
+	City    String `json:"city"`
+	Country String `json:"country"`
+}
+
+type patchRequest struct {
+	Name    String             `json:"name"`
+	Age     Int                `json:"age"`
+	Email   String             `json:"email"`
+	Tags    StringArray        `json:"tags"`
+	Score   Float              `json:"user_score"`
+	Address Type[patchAddress] `json:"address"`
+}
+
+type patchAddressModel struct {
+	City    string
+	Country string
+}
+
+type patchModel struct {
+	Name    string
+	Age     *int64
+	Email   String
+	Tags    []string
+	Rating  float64 `json:"user_score"`
+	Address patchAddressModel
+}
+
+func TestApplyPatch(t *testing.T) {
+	age := int64(20)
+	tests := []struct {
+		name   string
+		patch  string
+		dst    patchModel
+		expect patchModel
+	}{
+		{
+			name:   "undefined",
+			patch:  `{}`,
+			dst:    patchModel{Name: "name", Age: &age},
+			expect: patchModel{Name: "name", Age: &age},
+		},
+		{
+			name:   "null value",
+			patch:  `{"name":null,"age":null,"email":null}`,
+			dst:    patchModel{Name: "name", Age: &age, Email: NewString("email")},
+			expect: patchModel{Email: String{Present: true}},
+		},
+		{
+			name:  "valid value",
+			patch: `{"name":"new","age":21,"email":"mail","tags":["a","b"],"user_score":4.5}`,
+			dst:   patchModel{Name: "name", Age: &age},
+			expect: patchModel{
+				Name:   "new",
+				Age:    NewInt(21).Ptr(),
+				Email:  NewString("mail"),
+				Tags:   []string{"a", "b"},
+				Rating: 4.5,
+			},
+		},
+		{
+			name:  "nested value",
+			patch: `{"address":{"city":"Denpasar"}}`,
+			dst:   patchModel{Address: patchAddressModel{City: "Jakarta", Country: "Indonesia"}},
+			expect: patchModel{
+				Address: patchAddressModel{City: "Denpasar", Country: "Indonesia"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch patchRequest
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			got := tt.dst
+			if err := ApplyPatch(&got, patch); err != nil {
+				t.Fatalf("unexpected patch error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("expected value to be %#v got %#v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestApplyPatch_InvalidDestination(t *testing.T) {
+	if err := ApplyPatch(patchModel{}, patchRequest{}); err == nil {
+		t.Errorf("expected error for non pointer destination")
+	}
+}
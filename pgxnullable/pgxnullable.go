@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+// Package pgxnullable registers nullable types into pgx v5,
+// so they are encoded and decoded with the postgres binary format.
+//
+// The nullable types implement the pgtype scanner and valuer interfaces (e.g. pgtype.TextScanner),
+// so scanning works without registration. Registration is needed for pgx to know the postgres type
+// of a nullable value when the parameter type is not known, e.g. with pgx.QueryExecModeExec or CopyFrom,
+// and for the PostGIS `geometry` type, which has no fixed OID.
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//	    pgxnullable.Register(conn.TypeMap())
+//	    pgxnullable.RegisterJSONB[Address](conn.TypeMap())
+//	    return pgxnullable.RegisterGeometry(ctx, conn)
+//	}
+package pgxnullable
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.portalnesia.com/nullable"
+)
+
+// Register registers the default postgres type of nullable types into m.
+func Register(m *pgtype.Map) {
+	m.RegisterDefaultPgType(nullable.String{}, "text")
+	m.RegisterDefaultPgType(nullable.Int{}, "int8")
+	m.RegisterDefaultPgType(nullable.Int8{}, "int2")
+	m.RegisterDefaultPgType(nullable.Int16{}, "int2")
+	m.RegisterDefaultPgType(nullable.Int32{}, "int4")
+	m.RegisterDefaultPgType(nullable.Uint{}, "numeric")
+	m.RegisterDefaultPgType(nullable.Uint8{}, "int2")
+	m.RegisterDefaultPgType(nullable.Uint16{}, "int4")
+	m.RegisterDefaultPgType(nullable.Uint32{}, "int8")
+	m.RegisterDefaultPgType(nullable.Uint64{}, "numeric")
+	m.RegisterDefaultPgType(nullable.Float{}, "float8")
+	m.RegisterDefaultPgType(nullable.Float32{}, "float4")
+	m.RegisterDefaultPgType(nullable.Decimal{}, "numeric")
+	m.RegisterDefaultPgType(nullable.Bool{}, "bool")
+	m.RegisterDefaultPgType(nullable.Time{}, "timestamptz")
+	m.RegisterDefaultPgType(nullable.UnixTime{}, "int8")
+	m.RegisterDefaultPgType(nullable.UnixMilliTime{}, "int8")
+	m.RegisterDefaultPgType(nullable.Date{}, "date")
+	m.RegisterDefaultPgType(nullable.TimeOfDay{}, "time")
+	m.RegisterDefaultPgType(nullable.Duration{}, "interval")
+	m.RegisterDefaultPgType(nullable.UUID{}, "uuid")
+	m.RegisterDefaultPgType(nullable.StringArray{}, "_text")
+	m.RegisterDefaultPgType(nullable.IntArray{}, "_int8")
+	m.RegisterDefaultPgType(nullable.FloatArray{}, "_float8")
+	m.RegisterDefaultPgType(nullable.BoolArray{}, "_bool")
+	m.RegisterDefaultPgType(nullable.TimeArray{}, "_timestamptz")
+	m.RegisterDefaultPgType(nullable.UUIDArray{}, "_uuid")
+}
+
+// RegisterJSONB registers `jsonb` as the default postgres type of nullable.Type[D] into m.
+func RegisterJSONB[D any](m *pgtype.Map) {
+	m.RegisterDefaultPgType(nullable.Type[D]{}, "jsonb")
+}
+
+// RegisterGeometry loads the OID of PostGIS `geometry` type from conn, and registers it into the type map of conn
+// with EWKB binary format, as the default postgres type of nullable geometry types.
+//
+// It returns an error if PostGIS extension is not installed.
+func RegisterGeometry(ctx context.Context, conn *pgx.Conn) error {
+	var oid uint32
+	if err := conn.QueryRow(ctx, "SELECT 'geometry'::regtype::oid").Scan(&oid); err != nil {
+		return err
+	}
+	registerGeometry(conn.TypeMap(), oid)
+	return nil
+}
+
+func registerGeometry(m *pgtype.Map, oid uint32) {
+	// The binary format of PostGIS geometry is EWKB, which is handled by
+	// pgtype.BytesScanner and pgtype.BytesValuer of nullable geometry types.
+	m.RegisterType(&pgtype.Type{Name: "geometry", OID: oid, Codec: pgtype.ByteaCodec{}})
+
+	for _, v := range []any{
+		nullable.GeomPoint{},
+		nullable.GeomMultiPoint{},
+		nullable.GeomLineString{},
+		nullable.GeomMultiLineString{},
+		nullable.GeomPolygon{},
+		nullable.GeomMultiPolygon{},
+		nullable.GeomCollection{},
+		nullable.Geometry{},
+	} {
+		m.RegisterDefaultPgType(v, "geometry")
+	}
+}
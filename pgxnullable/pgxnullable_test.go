@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package pgxnullable
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/paulmach/orb"
+	"go.portalnesia.com/nullable"
+)
+
+type addressTest struct {
+	City string `json:"city"`
+}
+
+// roundTrip encodes value with binary format and scans it back into dst.
+func roundTrip(t *testing.T, m *pgtype.Map, name string, value, dst any) {
+	t.Helper()
+
+	typ, ok := m.TypeForName(name)
+	if !ok {
+		t.Fatalf("unknown type %s", name)
+	}
+	buf, err := m.Encode(typ.OID, pgtype.BinaryFormatCode, value, nil)
+	if err != nil {
+		t.Fatalf("unexpected encoding error: %s", err)
+	}
+	if err = m.Scan(typ.OID, pgtype.BinaryFormatCode, buf, dst); err != nil {
+		t.Fatalf("unexpected scanning error: %s", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	RegisterJSONB[addressTest](m)
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+		dst   any
+	}{
+		{name: "text", value: nullable.NewString("hello"), dst: new(nullable.String)},
+		{name: "int8", value: nullable.NewInt(42), dst: new(nullable.Int)},
+		{name: "float8", value: nullable.NewFloat(1.5), dst: new(nullable.Float)},
+		{name: "bool", value: nullable.NewBool(true), dst: new(nullable.Bool)},
+		{name: "int2", value: nullable.NewNumber[int16](7), dst: new(nullable.Int16)},
+		{name: "int4", value: nullable.NewNumber[int32](-7), dst: new(nullable.Int32)},
+		{name: "float4", value: nullable.NewNumber[float32](1.5), dst: new(nullable.Float32)},
+		{name: "date", value: nullable.NewDate(now), dst: new(nullable.Date)},
+		{name: "time", value: nullable.NewTimeOfDay(time.Date(0, 1, 1, 15, 4, 5, 123456000, time.UTC)), dst: new(nullable.TimeOfDay)},
+		{name: "interval", value: nullable.NewDuration(90*time.Minute + time.Microsecond), dst: new(nullable.Duration)},
+		{name: "uuid", value: nullable.NewUUID([16]byte{1, 2, 3}), dst: new(nullable.UUID)},
+		{name: "_text", value: nullable.NewStringArray([]string{"a", "b"}), dst: new(nullable.StringArray)},
+		{name: "_int8", value: nullable.NewArray([]int64{1, 2}), dst: new(nullable.IntArray)},
+		{name: "_float8", value: nullable.NewArray([]float64{1.5}), dst: new(nullable.FloatArray)},
+		{name: "_bool", value: nullable.NewArray([]bool{true, false}), dst: new(nullable.BoolArray)},
+		{name: "jsonb", value: nullable.NewType(addressTest{City: "Denpasar"}), dst: new(nullable.Type[addressTest])},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roundTrip(t, m, tt.name, tt.value, tt.dst)
+			got := reflect.ValueOf(tt.dst).Elem().Interface()
+			if !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("expected value to be %+v got %+v", tt.value, got)
+			}
+		})
+	}
+}
+
+func TestRegister_Time(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	now := time.Date(2024, 1, 2, 15, 4, 5, 123456000, time.UTC)
+
+	var got nullable.Time
+	roundTrip(t, m, "timestamptz", nullable.NewTime(now), &got)
+	if !got.Valid || !got.Data.Equal(now) {
+		t.Errorf("expected value to be %s got %s", now, got.Data)
+	}
+
+	var times nullable.TimeArray
+	roundTrip(t, m, "_timestamptz", nullable.NewArray([]time.Time{now}), &times)
+	if !times.Valid || len(times.Data) != 1 || !times.Data[0].Equal(now) {
+		t.Errorf("expected value to be %s got %v", now, times.Data)
+	}
+}
+
+func TestRegister_Numeric(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	var decimal nullable.Decimal
+	roundTrip(t, m, "numeric", nullable.NewDecimal(big.NewRat(-12345, 100)), &decimal)
+	if !decimal.Valid || decimal.String() != "-123.45" {
+		t.Errorf("expected value to be -123.45 got %s", decimal)
+	}
+
+	var uint64s nullable.Uint64
+	roundTrip(t, m, "numeric", nullable.NewNumber[uint64](math.MaxUint64), &uint64s)
+	if !uint64s.Valid || uint64s.Data != math.MaxUint64 {
+		t.Errorf("expected value to be %d got %d", uint64(math.MaxUint64), uint64s.Data)
+	}
+
+	var overflow nullable.Int8
+	typ, _ := m.TypeForName("numeric")
+	buf, err := m.Encode(typ.OID, pgtype.BinaryFormatCode, nullable.NewNumber[int32](300), nil)
+	if err != nil {
+		t.Fatalf("unexpected encoding error: %s", err)
+	}
+	if err = m.Scan(typ.OID, pgtype.BinaryFormatCode, buf, &overflow); !errors.Is(err, nullable.ErrNumberOverflow) {
+		t.Errorf("expected overflow error got %v", err)
+	}
+}
+
+func TestRegister_UnixTime(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	now := time.Date(2024, 1, 2, 15, 4, 5, 123000000, time.UTC)
+
+	var unix nullable.UnixTime
+	roundTrip(t, m, "int8", nullable.NewUnixTime(now), &unix)
+	if !unix.Valid || !unix.Data.Equal(now.Truncate(time.Second)) {
+		t.Errorf("expected value to be %s got %s", now.Truncate(time.Second), unix.Data)
+	}
+
+	var milli nullable.UnixMilliTime
+	roundTrip(t, m, "int8", nullable.NewUnixMilliTime(now), &milli)
+	if !milli.Valid || !milli.Data.Equal(now) {
+		t.Errorf("expected value to be %s got %s", now, milli.Data)
+	}
+
+	var timestamp nullable.UnixTime
+	roundTrip(t, m, "timestamptz", now, &timestamp)
+	if !timestamp.Valid || !timestamp.Data.Equal(now) {
+		t.Errorf("expected value to be %s got %s", now, timestamp.Data)
+	}
+}
+
+func TestRegister_Null(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	tests := []struct {
+		name  string
+		value any
+		dst   interface{ IsValid() bool }
+	}{
+		{name: "text", value: nullable.String{Present: true}, dst: new(nullable.String)},
+		{name: "int8", value: nullable.Int{}, dst: new(nullable.Int)},
+		{name: "timestamptz", value: nullable.Time{Present: true}, dst: new(nullable.Time)},
+		{name: "numeric", value: nullable.Decimal{Present: true}, dst: new(nullable.Decimal)},
+		{name: "interval", value: nullable.Duration{Present: true}, dst: new(nullable.Duration)},
+		{name: "time", value: nullable.TimeOfDay{Present: true}, dst: new(nullable.TimeOfDay)},
+		{name: "_text", value: nullable.StringArray{Present: true}, dst: new(nullable.StringArray)},
+		{name: "_int8", value: nullable.IntArray{Present: true}, dst: new(nullable.IntArray)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ, _ := m.TypeForName(tt.name)
+			buf, err := m.Encode(typ.OID, pgtype.BinaryFormatCode, tt.value, nil)
+			if err != nil {
+				t.Fatalf("unexpected encoding error: %s", err)
+			}
+			if buf != nil {
+				t.Fatalf("expected NULL got %v", buf)
+			}
+			if err = m.Scan(typ.OID, pgtype.BinaryFormatCode, nil, tt.dst); err != nil {
+				t.Fatalf("unexpected scanning error: %s", err)
+			}
+			if !tt.dst.(interface{ IsPresent() bool }).IsPresent() || tt.dst.IsValid() {
+				t.Errorf("expected present null value got %+v", tt.dst)
+			}
+		})
+	}
+}
+
+func TestRegisterGeometry(t *testing.T) {
+	m := pgtype.NewMap()
+	registerGeometry(m, 90000)
+
+	point := nullable.NewGeomPoint(orb.Point{115.2, -8.6})
+	point.SRID = 4326
+
+	var got nullable.GeomPoint
+	roundTrip(t, m, "geometry", point, &got)
+	if !got.Valid || got.SRID != 4326 || got.Point != point.Point {
+		t.Errorf("expected value to be %s got %s", point, got)
+	}
+
+	var geom nullable.Geometry
+	roundTrip(t, m, "geometry", point, &geom)
+	if p, ok := geom.Data.(orb.Point); !ok || p != (orb.Point{115.2, -8.6}) {
+		t.Errorf("expected value to be %s got %v", point, geom.Data)
+	}
+
+	if err := m.Scan(90000, pgtype.BinaryFormatCode, nil, &got); err != nil {
+		t.Fatalf("unexpected scanning error: %s", err)
+	}
+	if !got.Present || got.Valid {
+		t.Errorf("expected present null value got %+v", got)
+	}
+}
@@ -13,16 +13,68 @@ import (
 	"database/sql/driver"
 	"reflect"
 
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 
 	"encoding/json"
 
 	"gopkg.in/guregu/null.v4"
 )
 
+// EmptyPolicy controls whether an empty value is treated as null or as a valid value.
+type EmptyPolicy int
+
+const (
+	// EmptyDefault keeps the behaviour of the type without a policy:
+	// an empty value is decoded as not valid from JSON, BSON and msgpack,
+	// and as valid from the database. A valid empty value is written as is.
+	EmptyDefault EmptyPolicy = iota
+
+	// EmptyIsNull treats an empty value as null: it is decoded as not valid,
+	// and written as NULL to the database and null to JSON, BSON and msgpack.
+	EmptyIsNull
+
+	// EmptyIsValue treats an empty value as a valid value,
+	// e.g. `""` or `[]` in JSON, and `''` or `'{}'` in the database.
+	EmptyIsValue
+)
+
+var (
+	// StringEmptyPolicy is the EmptyPolicy of String. The default is EmptyDefault.
+	//
+	// It should be set once, before any query or decoding takes place.
+	StringEmptyPolicy = EmptyDefault
+
+	// StringArrayEmptyPolicy is the EmptyPolicy of StringArray. The default is EmptyDefault.
+	//
+	// It should be set once, before any query or decoding takes place.
+	StringArrayEmptyPolicy = EmptyDefault
+)
+
+// isNull reports whether a value of length n is null under the policy p.
+// emptyIsNull is the result for an empty value under EmptyDefault, which depends on the type and the path.
+func (p EmptyPolicy) isNull(n int, emptyIsNull bool) bool {
+	if n > 0 {
+		return false
+	}
+	switch p {
+	case EmptyIsNull:
+		return true
+	case EmptyIsValue:
+		return false
+	}
+	return emptyIsNull
+}
+
 // String represents a string that may be null or not
 // present in JSON at all.
+//
+// Empty string is null or valid according to StringEmptyPolicy.
 type String struct {
 	Present bool // Present is true if key is present in JSON
 	Valid   bool // Valid is true if value is not null and valid string
@@ -56,34 +108,46 @@ func (d String) IsPresent() bool {
 	return d.Present
 }
 
+// IsValid reports whether the value is valid and not null under StringEmptyPolicy.
 func (d String) IsValid() bool {
-	return d.Valid
+	return d.valid()
 }
 
 func (d String) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d String) IsZero() bool {
+	return !d.Present
+}
+
 func (d String) Null() null.String {
-	return null.NewString(d.Data, d.Present && d.Valid && d.Data != "")
+	return null.NewString(d.Data, d.Present && d.Valid && !StringEmptyPolicy.isNull(len(d.Data), true))
 }
 
 func (d String) Ptr() *string {
-	if d.Valid {
+	if d.valid() {
 		return &d.Data
 	}
 	return nil
 }
 
 var (
-	_ driver.Valuer       = (*String)(nil)
-	_ sql.Scanner         = (*String)(nil)
-	_ json.Marshaler      = (*String)(nil)
-	_ json.Unmarshaler    = (*String)(nil)
-	_ bson.Marshaler      = (*String)(nil)
-	_ bson.Unmarshaler    = (*String)(nil)
-	_ msgpack.Marshaler   = (*String)(nil)
-	_ msgpack.Unmarshaler = (*String)(nil)
+	_ driver.Valuer                    = (*String)(nil)
+	_ sql.Scanner                      = (*String)(nil)
+	_ json.Marshaler                   = (*String)(nil)
+	_ json.Unmarshaler                 = (*String)(nil)
+	_ bson.ValueMarshaler              = (*String)(nil)
+	_ bson.ValueUnmarshaler            = (*String)(nil)
+	_ msgpack.Marshaler                = (*String)(nil)
+	_ msgpack.Unmarshaler              = (*String)(nil)
+	_ pgtype.TextScanner               = (*String)(nil)
+	_ pgtype.TextValuer                = (*String)(nil)
+	_ schema.QueryAppender             = (*String)(nil)
+	_ gormschema.GormDataTypeInterface = (*String)(nil)
+	_ migrator.GormDataTypeInterface   = (*String)(nil)
 )
 
 // Scan implements sql.Scanner interface
@@ -94,24 +158,52 @@ func (d *String) Scan(value interface{}) error {
 	if err := i.Scan(value); err != nil {
 		return err
 	}
-	d.Valid = i.Valid
+	d.Valid = i.Valid && !StringEmptyPolicy.isNull(len(i.String), false)
 	d.Data = i.String
 	return nil
 }
 
 // Value implements driver.Valuer interface
 func (d String) Value() (driver.Value, error) {
-	if !d.Valid {
+	if !d.valid() {
 		return nil, nil
 	}
 	return d.Data, nil
 }
 
+// ScanText implements pgtype.TextScanner interface.
+func (d *String) ScanText(v pgtype.Text) error {
+	d.Present = true
+	d.Valid = v.Valid && !StringEmptyPolicy.isNull(len(v.String), false)
+	d.Data = v.String
+	return nil
+}
+
+// TextValue implements pgtype.TextValuer interface.
+func (d String) TextValue() (pgtype.Text, error) {
+	return pgtype.Text{String: d.Data, Valid: d.valid()}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d String) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (String) GormDataType() string {
+	return string(gormschema.String)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (String) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return db.Dialector.DataTypeOf(field)
+}
+
 // MarshalJSON implements json.Marshaler interface.
 func (d String) MarshalJSON() ([]byte, error) {
 	if !d.Present {
 		return []byte(`null`), nil
-	} else if !d.Valid {
+	} else if !d.valid() {
 		return []byte("null"), nil
 	}
 	return json.Marshal(d.Data)
@@ -125,54 +217,44 @@ func (d *String) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
-	if bytes.Equal(data, []byte(`""`)) {
-		return nil
-	}
-
 	if err := json.Unmarshal(data, &d.Data); err != nil {
-		return nil
+		return decodeError(data, "string", err)
 	}
 
-	d.Valid = true
+	d.Valid = !StringEmptyPolicy.isNull(len(d.Data), true)
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d String) MarshalBSON() (byt []byte, err error) {
-	var tmp *string
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d String) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.valid() {
+		return byte(bson.TypeNull), nil, nil
 	}
-	_, byt, err = bson.MarshalValue(d.Data)
-	return byt, err
+	t, byt, err := bson.MarshalValue(d.Data)
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *String) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *String) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
 		return nil
 	}
 
-	if bytes.Equal(data, []byte(`""`)) {
-		return nil
+	if err := raw.Unmarshal(&d.Data); err != nil {
+		return decodeError(data, "string", err)
 	}
 
-	if err := bson.Unmarshal(data, &d.Data); err != nil {
-		return nil
-	}
-
-	d.Valid = true
+	d.Valid = !StringEmptyPolicy.isNull(len(d.Data), true)
 	return nil
 }
 
 // MarshalMsgpack implements msgpack.Marshaler interface.
 func (d String) MarshalMsgpack() ([]byte, error) {
-	if !d.Present || !d.Valid {
+	if !d.Present || !d.valid() {
 		return msgpack.Marshal(nil)
 	}
 	return msgpack.Marshal(d.Data)
@@ -192,12 +274,18 @@ func (d *String) UnmarshalMsgpack(data []byte) error {
 		return nil
 	}
 
-	d.Valid = len(*val) > 0
+	d.Valid = !StringEmptyPolicy.isNull(len(*val), true)
 	d.Data = *val
 	return nil
 }
 
 func (String) FiberConverter(value string) reflect.Value {
-	a := NewString(value, true, true)
+	a := NewString(value, true, !StringEmptyPolicy.isNull(len(value), false))
 	return reflect.ValueOf(a)
 }
+
+// valid reports whether d is valid and not null under StringEmptyPolicy.
+// It is used by IsValid and every encoder, so they always agree.
+func (d String) valid() bool {
+	return d.Valid && !StringEmptyPolicy.isNull(len(d.Data), false)
+}
@@ -9,22 +9,30 @@ package nullable
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"reflect"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	pg "github.com/lib/pq"
 	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 )
 
 // StringArray represents an array of string that may be null or not
 // present in JSON at all.
 //
+// Empty array is null or valid according to StringArrayEmptyPolicy.
+//
 // When using with bun ORM, do NOT use `type:text[]` or `array` tag on the field,
 // as pgdialect will override the appender with arrayAppender which does not support struct types.
 // Instead, use nullzero tag and let the driver.Valuer handle the conversion:
@@ -59,7 +67,7 @@ func NewStringArrayPtr(data pg.StringArray, presentValid ...bool) *StringArray {
 }
 
 func (d StringArray) Ptr() *pg.StringArray {
-	if d.Valid {
+	if d.valid() {
 		return &d.Data
 	}
 	return nil
@@ -69,27 +77,41 @@ func (d StringArray) IsPresent() bool {
 	return d.Present
 }
 
+// IsValid reports whether the value is valid and not null under StringArrayEmptyPolicy.
 func (d StringArray) IsValid() bool {
-	return d.Valid
+	return d.valid()
 }
 
 func (d StringArray) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d StringArray) IsZero() bool {
+	return !d.Present
+}
+
 var (
-	_ driver.Valuer        = (*StringArray)(nil)
-	_ sql.Scanner          = (*StringArray)(nil)
-	_ json.Marshaler       = (*StringArray)(nil)
-	_ json.Unmarshaler     = (*StringArray)(nil)
-	_ bson.Marshaler       = (*StringArray)(nil)
-	_ bson.Unmarshaler     = (*StringArray)(nil)
-	_ msgpack.Marshaler    = (*StringArray)(nil)
-	_ msgpack.Unmarshaler  = (*StringArray)(nil)
-	_ schema.QueryAppender = (*StringArray)(nil)
+	_ driver.Valuer                    = (*StringArray)(nil)
+	_ sql.Scanner                      = (*StringArray)(nil)
+	_ json.Marshaler                   = (*StringArray)(nil)
+	_ json.Unmarshaler                 = (*StringArray)(nil)
+	_ bson.ValueMarshaler              = (*StringArray)(nil)
+	_ bson.ValueUnmarshaler            = (*StringArray)(nil)
+	_ msgpack.Marshaler                = (*StringArray)(nil)
+	_ msgpack.Unmarshaler              = (*StringArray)(nil)
+	_ schema.QueryAppender             = (*StringArray)(nil)
+	_ pgtype.ArraySetter               = (*StringArray)(nil)
+	_ pgtype.ArrayGetter               = (*StringArray)(nil)
+	_ gormschema.GormDataTypeInterface = (*StringArray)(nil)
+	_ migrator.GormDataTypeInterface   = (*StringArray)(nil)
+	_ gorm.Valuer                      = (*StringArray)(nil)
 )
 
 // Scan implements sql.Scanner interface
+//
+// Both postgres array text, e.g. `{a,b}`, and JSON array, e.g. from MySQL `JSON` column, are accepted.
 func (d *StringArray) Scan(value interface{}) error {
 	d.Present = true
 	if value == nil {
@@ -97,42 +119,117 @@ func (d *StringArray) Scan(value interface{}) error {
 		return nil
 	}
 
-	var temp pg.StringArray
-	if err := temp.Scan(value); err != nil {
+	var (
+		temp pg.StringArray
+		err  error
+	)
+	if data, ok := jsonArray(value); ok {
+		err = json.Unmarshal(data, &temp)
+	} else {
+		err = temp.Scan(value)
+	}
+	if err != nil {
 		d.Valid = false
 		return err
 	}
 
-	d.Valid = true
+	d.Valid = !StringArrayEmptyPolicy.isNull(len(temp), false)
 	d.Data = temp
 	return nil
 }
 
 // Value implements driver.Valuer interface
 func (d StringArray) Value() (driver.Value, error) {
-	if !d.Valid || len(d.Data) == 0 {
+	if !d.valid() {
 		return nil, nil
 	}
 
-	return d.Data.Value()
+	return d.data().Value()
 }
 
 // AppendQuery implements bun/dialect.AppendQuery interface for PostgreSQL array support.
 func (d StringArray) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
-	if !d.Valid {
+	if !d.valid() {
 		return dialect.AppendNull(b), nil
 	}
-	return pgdialect.Array([]string(d.Data)).AppendQuery(gen, b)
+	return pgdialect.Array([]string(d.data())).AppendQuery(gen, b)
+}
+
+// SetDimensions implements pgtype.ArraySetter interface.
+func (d *StringArray) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	d.Present = true
+	if dimensions == nil {
+		d.Valid = false
+		d.Data = nil
+		return nil
+	}
+
+	n := arrayLength(dimensions)
+	d.Valid = !StringArrayEmptyPolicy.isNull(n, false)
+	d.Data = make(pg.StringArray, n)
+	return nil
+}
+
+// ScanIndex implements pgtype.ArraySetter interface.
+func (d *StringArray) ScanIndex(i int) any {
+	return &d.Data[i]
+}
+
+// ScanIndexType implements pgtype.ArraySetter interface.
+func (d StringArray) ScanIndexType() any {
+	return new(string)
+}
+
+// Dimensions implements pgtype.ArrayGetter interface.
+func (d StringArray) Dimensions() []pgtype.ArrayDimension {
+	if !d.valid() {
+		return nil
+	}
+	return []pgtype.ArrayDimension{{Length: int32(len(d.Data)), LowerBound: 1}}
+}
+
+// Index implements pgtype.ArrayGetter interface.
+func (d StringArray) Index(i int) any {
+	return d.Data[i]
+}
+
+// IndexType implements pgtype.ArrayGetter interface.
+func (d StringArray) IndexType() any {
+	return ""
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (StringArray) GormDataType() string {
+	return "text[]"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (StringArray) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return gormColumnType{Postgres: "text[]", MySQL: "JSON", SQLite: "JSON", SQLServer: "NVARCHAR(MAX)"}.of(db)
+}
+
+// GormValue implements gorm.Valuer interface.
+//
+// StringArray is written as postgres array, or as JSON array in other databases (casted to JSON in MySQL).
+func (d StringArray) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if !d.valid() {
+		return gormNull
+	}
+	if db.Dialector.Name() == gormPostgres {
+		return gormValue(db, d)
+	}
+	data, err := json.Marshal(d.data())
+	return gormJSON(db, data, err)
 }
 
 // MarshalJSON implements json.Marshaler interface.
 func (d StringArray) MarshalJSON() ([]byte, error) {
 	if !d.Present {
 		return []byte(`null`), nil
-	} else if !d.Valid {
+	} else if !d.valid() {
 		return []byte(`null`), nil
 	}
-	return json.Marshal(d.Data)
+	return json.Marshal(d.data())
 }
 
 // UnmarshalJSON implements json.Marshaler interface.
@@ -143,49 +240,44 @@ func (d *StringArray) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 	if err := json.Unmarshal(data, &d.Data); err != nil {
-		return err
-	}
-	if len(d.Data) > 0 {
-		d.Valid = true
+		return rejectError(data, "string array", err)
 	}
+	d.Valid = !StringArrayEmptyPolicy.isNull(len(d.Data), true)
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d StringArray) MarshalBSON() (byt []byte, err error) {
-	var tmp *string
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d StringArray) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.valid() {
+		return byte(bson.TypeNull), nil, nil
 	}
-	_, byt, err = bson.MarshalValue(d.Data)
-	return byt, err
+	t, byt, err := bson.MarshalValue(d.data())
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *StringArray) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *StringArray) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
 		return nil
 	}
-	if err := bson.Unmarshal(data, &d.Data); err != nil {
-		return err
-	}
-	if len(d.Data) > 0 {
-		d.Valid = true
+
+	if err := raw.Unmarshal(&d.Data); err != nil {
+		return rejectError(data, "string array", err)
 	}
+	d.Valid = !StringArrayEmptyPolicy.isNull(len(d.Data), true)
 	return nil
 }
 
 // MarshalMsgpack implements msgpack.Marshaler interface.
 func (d StringArray) MarshalMsgpack() ([]byte, error) {
-	if !d.Present || !d.Valid {
+	if !d.Present || !d.valid() {
 		return msgpack.Marshal(nil)
 	}
-	return msgpack.Marshal(d.Data)
+	return msgpack.Marshal(d.data())
 }
 
 // UnmarshalMsgpack implements msgpack.Unmarshaler interface.
@@ -202,7 +294,7 @@ func (d *StringArray) UnmarshalMsgpack(data []byte) error {
 		return nil
 	}
 
-	d.Valid = len(*val) > 0
+	d.Valid = !StringArrayEmptyPolicy.isNull(len(*val), true)
 	d.Data = *val
 	return nil
 }
@@ -215,21 +307,23 @@ func (StringArray) FiberConverter(value string) reflect.Value {
 		pg.StringArray{},
 	}
 
-	if err := json.Unmarshal([]byte(value), &tmp); err != nil {
-		s = NewStringArray(tmp, true, true)
+	if err := json.Unmarshal([]byte(value), &tmp); err == nil {
+		s = NewStringArray(tmp, true, !StringArrayEmptyPolicy.isNull(len(tmp), true))
 	}
 
 	return reflect.ValueOf(s)
 }
 
-//func (d StringArray) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
-//	data, _ := d.MarshalJSON()
-//	switch db.Dialector.Name() {
-//	case "mysql":
-//		if v, ok := db.Dialector.(*mysql.Dialector); ok && !strings.Contains(v.ServerVersion, "MariaDB") {
-//			return gorm.Expr("CAST(? AS JSON)", string(data))
-//		}
-//	}
-//
-//	return clause.Expr{}
-//}
+// valid reports whether d is valid and not null under StringArrayEmptyPolicy.
+// It is used by IsValid and every encoder, so they always agree.
+func (d StringArray) valid() bool {
+	return d.Valid && !StringArrayEmptyPolicy.isNull(len(d.Data), false)
+}
+
+// data returns Data, or an empty array if Data is nil, so a valid array is never encoded as null.
+func (d StringArray) data() pg.StringArray {
+	if d.Data == nil {
+		return pg.StringArray{}
+	}
+	return d.Data
+}
@@ -16,7 +16,7 @@ import (
 )
 
 type typeStringArrayTest struct {
-	Value StringArray `json:"value"`
+	Value StringArray `json:"value,omitzero"`
 }
 
 func TestStringArray_MarshalJSON(t *testing.T) {
@@ -25,6 +25,11 @@ func TestStringArray_MarshalJSON(t *testing.T) {
 		data   typeStringArrayTest
 		expect *bytes.Buffer
 	}{
+		{
+			name:   "undefined value",
+			data:   typeStringArrayTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
 		{
 			name: "null value",
 			data: typeStringArrayTest{
@@ -117,3 +122,94 @@ func TestStringArray_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestStringArray_EmptyPolicy(t *testing.T) {
+	defer func(p EmptyPolicy) { StringArrayEmptyPolicy = p }(StringArrayEmptyPolicy)
+
+	tests := []struct {
+		policy      EmptyPolicy
+		decodeValid bool
+		valid       bool
+		expectJSON  string
+		expectValue interface{}
+	}{
+		{policy: EmptyDefault, decodeValid: false, valid: true, expectJSON: `[]`, expectValue: "{}"},
+		{policy: EmptyIsNull, decodeValid: false, valid: false, expectJSON: `null`, expectValue: nil},
+		{policy: EmptyIsValue, decodeValid: true, valid: true, expectJSON: `[]`, expectValue: "{}"},
+	}
+
+	for _, tt := range tests {
+		StringArrayEmptyPolicy = tt.policy
+
+		var decoded StringArray
+		if err := json.Unmarshal([]byte(`[]`), &decoded); err != nil {
+			t.Fatalf("unexpected unmarshaling error: %s", err)
+		}
+		if !decoded.Present || decoded.IsValid() != tt.decodeValid {
+			t.Errorf("policy %d: expected valid to be %t got %t", tt.policy, tt.decodeValid, decoded.IsValid())
+		}
+
+		var scanned StringArray
+		if err := scanned.Scan([]byte(`{}`)); err != nil {
+			t.Fatalf("unexpected scan error: %s", err)
+		}
+		if scanned.IsValid() != tt.valid {
+			t.Errorf("policy %d: expected scanned valid to be %t got %t", tt.policy, tt.valid, scanned.IsValid())
+		}
+		if byt, _ := json.Marshal(scanned); string(byt) != tt.expectJSON {
+			t.Errorf("policy %d: expected scanned value to be %s got %s", tt.policy, tt.expectJSON, byt)
+		}
+
+		data := NewStringArray(pg.StringArray{})
+		if data.IsValid() != tt.valid {
+			t.Errorf("policy %d: expected valid to be %t got %t", tt.policy, tt.valid, data.IsValid())
+		}
+		byt, err := json.Marshal(data)
+		if err != nil {
+			t.Fatalf("unexpected marshaling error: %s", err)
+		}
+		if string(byt) != tt.expectJSON {
+			t.Errorf("policy %d: expected value to be %s got %s", tt.policy, tt.expectJSON, byt)
+		}
+		value, err := data.Value()
+		if err != nil {
+			t.Fatalf("unexpected value error: %s", err)
+		}
+		if value != tt.expectValue {
+			t.Errorf("policy %d: expected value to be %v got %v", tt.policy, tt.expectValue, value)
+		}
+
+		// IsValid, Value and JSON must agree, whatever the origin of the value.
+		for _, d := range []StringArray{decoded, scanned, data} {
+			value, _ := d.Value()
+			byt, _ := json.Marshal(d)
+			if (value != nil) != d.IsValid() || (string(byt) != "null") != d.IsValid() {
+				t.Errorf("policy %d: expected IsValid %t to agree with value %v and JSON %s", tt.policy, d.IsValid(), value, byt)
+			}
+		}
+	}
+}
+
+func TestStringArray_Scan(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		expect StringArray
+	}{
+		{name: "null value", value: nil, expect: StringArray{Present: true}},
+		{name: "postgres array", value: []byte(`{a,"b c"}`), expect: NewStringArray(pg.StringArray{"a", "b c"})},
+		{name: "json array", value: `["a","b c"]`, expect: NewStringArray(pg.StringArray{"a", "b c"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got StringArray
+			if err := got.Scan(tt.value); err != nil {
+				t.Fatalf("unexpected scan error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.expect) {
+				t.Errorf("expected value to be %#v got %#v", tt.expect, got)
+			}
+		})
+	}
+}
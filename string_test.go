@@ -15,7 +15,7 @@ import (
 )
 
 type stringJsonTest struct {
-	Value String `json:"value,omitempty"`
+	Value String `json:"value,omitzero"`
 }
 
 func TestString_MarshalJSON(t *testing.T) {
@@ -24,6 +24,11 @@ func TestString_MarshalJSON(t *testing.T) {
 		data   stringJsonTest
 		expect *bytes.Buffer
 	}{
+		{
+			name:   "undefined value",
+			data:   stringJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
 		{
 			name: "null value",
 			data: stringJsonTest{
@@ -108,3 +113,68 @@ func TestString_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestString_EmptyPolicy(t *testing.T) {
+	defer func(p EmptyPolicy) { StringEmptyPolicy = p }(StringEmptyPolicy)
+
+	tests := []struct {
+		policy      EmptyPolicy
+		decodeValid bool
+		scanValid   bool
+		valid       bool
+		expectJSON  string
+		expectValue interface{}
+	}{
+		{policy: EmptyDefault, decodeValid: false, scanValid: true, valid: true, expectJSON: `""`, expectValue: ""},
+		{policy: EmptyIsNull, decodeValid: false, scanValid: false, valid: false, expectJSON: `null`, expectValue: nil},
+		{policy: EmptyIsValue, decodeValid: true, scanValid: true, valid: true, expectJSON: `""`, expectValue: ""},
+	}
+
+	for _, tt := range tests {
+		StringEmptyPolicy = tt.policy
+
+		var decoded String
+		if err := json.Unmarshal([]byte(`""`), &decoded); err != nil {
+			t.Fatalf("unexpected unmarshaling error: %s", err)
+		}
+		if !decoded.Present || decoded.IsValid() != tt.decodeValid {
+			t.Errorf("policy %d: expected valid to be %t got %t", tt.policy, tt.decodeValid, decoded.IsValid())
+		}
+
+		var scanned String
+		if err := scanned.Scan(""); err != nil {
+			t.Fatalf("unexpected scan error: %s", err)
+		}
+		if scanned.IsValid() != tt.scanValid {
+			t.Errorf("policy %d: expected scanned valid to be %t got %t", tt.policy, tt.scanValid, scanned.IsValid())
+		}
+
+		data := NewString("")
+		if data.IsValid() != tt.valid {
+			t.Errorf("policy %d: expected valid to be %t got %t", tt.policy, tt.valid, data.IsValid())
+		}
+		byt, err := json.Marshal(data)
+		if err != nil {
+			t.Fatalf("unexpected marshaling error: %s", err)
+		}
+		if string(byt) != tt.expectJSON {
+			t.Errorf("policy %d: expected value to be %s got %s", tt.policy, tt.expectJSON, byt)
+		}
+		value, err := data.Value()
+		if err != nil {
+			t.Fatalf("unexpected value error: %s", err)
+		}
+		if value != tt.expectValue {
+			t.Errorf("policy %d: expected value to be %v got %v", tt.policy, tt.expectValue, value)
+		}
+
+		// IsValid, Value and JSON must agree, whatever the origin of the value.
+		for _, d := range []String{decoded, scanned, data} {
+			value, _ := d.Value()
+			byt, _ := json.Marshal(d)
+			if (value != nil) != d.IsValid() || (string(byt) != "null") != d.IsValid() {
+				t.Errorf("policy %d: expected IsValid %t to agree with value %v and JSON %s", tt.policy, d.IsValid(), value, byt)
+			}
+		}
+	}
+}
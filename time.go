@@ -12,18 +12,50 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/dromara/carbon/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 
 	"encoding/json"
 
 	"gopkg.in/guregu/null.v4"
 )
 
+// Special values of TimeFormat to marshal Time as JSON number.
+const (
+	TimeFormatUnix      = "unix"      // TimeFormatUnix marshals Time as unix seconds
+	TimeFormatUnixMilli = "unixmilli" // TimeFormatUnixMilli marshals Time as unix milliseconds
+)
+
+// Time JSON configuration. It should be set once, before any marshaling takes place.
+var (
+	// TimeFormat is the layout used by Time.MarshalJSON, or one of TimeFormatUnix and TimeFormatUnixMilli.
+	TimeFormat = time.RFC3339Nano
+
+	// TimeLocation converts Time to the location before marshaling, e.g. Asia/Jakarta.
+	// Nil keeps the location of the value.
+	TimeLocation *time.Location
+
+	// TimePrecision truncates Time to the precision before marshaling, e.g. time.Millisecond.
+	// Zero or negative keeps the value as it is.
+	TimePrecision time.Duration
+
+	// TimeInputLayouts restricts the layouts accepted by Time.UnmarshalJSON, Time.UnmarshalBSONValue,
+	// Time.UnmarshalMsgpack and Time.FiberConverter, in addition to TimeFormat, which is always tried first.
+	// When empty, any format that carbon can guess is accepted.
+	TimeInputLayouts []string
+)
+
 // Time represents go time that may be null or not
 // present in JSON at all.
 type Time struct {
@@ -66,6 +98,12 @@ func (d Time) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Time) IsZero() bool {
+	return !d.Present
+}
+
 func (d Time) Null() null.Time {
 	return null.NewTime(d.Data, d.Present && d.Valid)
 }
@@ -82,14 +120,21 @@ func (d Time) Carbon() *carbon.Carbon {
 }
 
 var (
-	_ driver.Valuer       = (*Time)(nil)
-	_ sql.Scanner         = (*Time)(nil)
-	_ json.Marshaler      = (*Time)(nil)
-	_ json.Unmarshaler    = (*Time)(nil)
-	_ bson.Marshaler      = (*Time)(nil)
-	_ bson.Unmarshaler    = (*Time)(nil)
-	_ msgpack.Marshaler   = (*Time)(nil)
-	_ msgpack.Unmarshaler = (*Time)(nil)
+	_ driver.Valuer                    = (*Time)(nil)
+	_ sql.Scanner                      = (*Time)(nil)
+	_ json.Marshaler                   = (*Time)(nil)
+	_ json.Unmarshaler                 = (*Time)(nil)
+	_ bson.ValueMarshaler              = (*Time)(nil)
+	_ bson.ValueUnmarshaler            = (*Time)(nil)
+	_ msgpack.Marshaler                = (*Time)(nil)
+	_ msgpack.Unmarshaler              = (*Time)(nil)
+	_ pgtype.TimestamptzScanner        = (*Time)(nil)
+	_ pgtype.TimestamptzValuer         = (*Time)(nil)
+	_ pgtype.TimestampScanner          = (*Time)(nil)
+	_ pgtype.TimestampValuer           = (*Time)(nil)
+	_ schema.QueryAppender             = (*Time)(nil)
+	_ gormschema.GormDataTypeInterface = (*Time)(nil)
+	_ migrator.GormDataTypeInterface   = (*Time)(nil)
 )
 
 // Scan implements sql.Scanner interface
@@ -114,14 +159,87 @@ func (d Time) Value() (driver.Value, error) {
 	return d.Data, nil
 }
 
+// ScanTimestamptz implements pgtype.TimestamptzScanner interface.
+//
+// Infinite timestamp is rejected, as it has no time.Time representation.
+func (d *Time) ScanTimestamptz(v pgtype.Timestamptz) error {
+	return d.scanPgTime(v.Time, v.InfinityModifier, v.Valid)
+}
+
+// TimestamptzValue implements pgtype.TimestamptzValuer interface.
+func (d Time) TimestamptzValue() (pgtype.Timestamptz, error) {
+	return pgtype.Timestamptz{Time: d.Data, Valid: d.Valid}, nil
+}
+
+// ScanTimestamp implements pgtype.TimestampScanner interface.
+//
+// Infinite timestamp is rejected, as it has no time.Time representation.
+func (d *Time) ScanTimestamp(v pgtype.Timestamp) error {
+	return d.scanPgTime(v.Time, v.InfinityModifier, v.Valid)
+}
+
+// TimestampValue implements pgtype.TimestampValuer interface.
+func (d Time) TimestampValue() (pgtype.Timestamp, error) {
+	return pgtype.Timestamp{Time: d.Data, Valid: d.Valid}, nil
+}
+
+func (d *Time) scanPgTime(t time.Time, infinity pgtype.InfinityModifier, valid bool) error {
+	d.Present = true
+	d.Valid = false
+
+	if !valid {
+		return nil
+	}
+	if infinity != pgtype.Finite {
+		return fmt.Errorf("nullable: cannot scan %s timestamp", infinity)
+	}
+	d.Valid = true
+	d.Data = t
+	d.carbon = carbon.CreateFromStdTime(t)
+	return nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d Time) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Time) GormDataType() string {
+	return string(gormschema.Time)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Time) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return db.Dialector.DataTypeOf(field)
+}
+
 // MarshalJSON implements json.Marshaler interface.
+//
+// The output follows TimeFormat, TimeLocation and TimePrecision.
 func (d Time) MarshalJSON() ([]byte, error) {
 	if !d.Present {
 		return []byte(`null`), nil
 	} else if !d.Valid {
 		return []byte("null"), nil
 	}
-	return json.Marshal(d.Data)
+
+	t := d.Data
+	if TimeLocation != nil {
+		t = t.In(TimeLocation)
+	}
+	if TimePrecision > 0 {
+		t = t.Truncate(TimePrecision)
+	}
+
+	switch TimeFormat {
+	case TimeFormatUnix:
+		return strconv.AppendInt(nil, t.Unix(), 10), nil
+	case TimeFormatUnixMilli:
+		return strconv.AppendInt(nil, t.UnixMilli(), 10), nil
+	default:
+		return json.Marshal(t.Format(TimeFormat))
+	}
 }
 
 // UnmarshalJSON implements json.Marshaler interface.
@@ -132,15 +250,28 @@ func (d *Time) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	if TimeFormat == TimeFormatUnix || TimeFormat == TimeFormatUnixMilli {
+		if epoch, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+			carbonTime := carbon.CreateFromTimestamp(epoch)
+			if TimeFormat == TimeFormatUnixMilli {
+				carbonTime = carbon.CreateFromTimestampMilli(epoch)
+			}
+			d.Data = carbonTime.StdTime()
+			d.Valid = true
+			d.carbon = carbonTime
+			return nil
+		}
+	}
+
 	var timeString string
 
 	if err := json.Unmarshal(data, &timeString); err != nil {
-		return err
+		return rejectError(data, "time", err)
 	}
 
-	carbonTime := carbon.Parse(timeString)
+	carbonTime := parseCarbon(timeString)
 	if !carbonTime.IsValid() {
-		return errors.New("invalid date string")
+		return rejectError(data, "time", errors.New("invalid date string"))
 	}
 	d.Data = carbonTime.StdTime()
 	d.Valid = true
@@ -148,37 +279,43 @@ func (d *Time) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d Time) MarshalBSON() (byt []byte, err error) {
-	var tmp *time.Time
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// Time is encoded as BSON DateTime.
+func (d Time) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
 	}
-	_, byt, err = bson.MarshalValue(d.Data)
-	return byt, err
+	t, byt, err := bson.MarshalValue(bson.NewDateTimeFromTime(d.Data))
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *Time) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+//
+// BSON DateTime, Timestamp, string and null are accepted.
+func (d *Time) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	var carbonTime *carbon.Carbon
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
 		return nil
+	case bson.TypeDateTime:
+		carbonTime = carbon.CreateFromStdTime(raw.Time())
+	case bson.TypeTimestamp:
+		sec, _ := raw.Timestamp()
+		carbonTime = carbon.CreateFromStdTime(time.Unix(int64(sec), 0))
+	case bson.TypeString:
+		carbonTime = parseCarbon(raw.StringValue())
+		if !carbonTime.IsValid() {
+			return rejectError(data, "time", errors.New("invalid date string"))
+		}
+	default:
+		return rejectError(data, "time", fmt.Errorf("nullable: unsupported bson type %s for time", raw.Type))
 	}
 
-	var timeString string
-
-	if err := bson.Unmarshal(data, &timeString); err != nil {
-		return err
-	}
-
-	carbonTime := carbon.Parse(timeString)
-	if !carbonTime.IsValid() {
-		return errors.New("invalid date string")
-	}
 	d.Data = carbonTime.StdTime()
 	d.Valid = true
 	d.carbon = carbonTime
@@ -207,7 +344,7 @@ func (d *Time) UnmarshalMsgpack(data []byte) error {
 		return nil
 	}
 
-	carbonTime := carbon.Parse(*val)
+	carbonTime := parseCarbon(*val)
 	if !carbonTime.IsValid() {
 		return errors.New("invalid date string")
 	}
@@ -218,7 +355,7 @@ func (d *Time) UnmarshalMsgpack(data []byte) error {
 }
 
 func (Time) FiberConverter(value string) reflect.Value {
-	c := carbon.Parse(value)
+	c := parseCarbon(value)
 	a := NewTime(c.StdTime(), true, false)
 	if c.IsValid() {
 		a.Valid = true
@@ -226,3 +363,23 @@ func (Time) FiberConverter(value string) reflect.Value {
 
 	return reflect.ValueOf(a)
 }
+
+// parseCarbon parses value with TimeFormat, then with TimeInputLayouts,
+// or with any format that carbon can guess if it is empty.
+//
+// Value without time zone is parsed in TimeLocation, as it is the location TimeFormat is written in.
+func parseCarbon(value string) *carbon.Carbon {
+	if TimeFormat != TimeFormatUnix && TimeFormat != TimeFormatUnixMilli {
+		loc := TimeLocation
+		if loc == nil {
+			loc = time.UTC
+		}
+		if t, err := time.ParseInLocation(TimeFormat, value, loc); err == nil {
+			return carbon.CreateFromStdTime(t)
+		}
+	}
+	if len(TimeInputLayouts) > 0 {
+		return carbon.ParseByLayouts(value, TimeInputLayouts)
+	}
+	return carbon.Parse(value)
+}
@@ -0,0 +1,335 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+)
+
+const (
+	timeOfDayLayout     = "15:04:05.999999999"
+	timeOfDayZoneLayout = "15:04:05.999999999Z07:00"
+)
+
+// timeOfDayLayouts is the accepted layouts of TimeOfDay input, e.g. postgres `time` and `timetz` output.
+// Fractional seconds are accepted by time.Parse even if the layout does not have it.
+var timeOfDayLayouts = []string{
+	"15:04:05",
+	"15:04:05Z07:00",
+	"15:04:05Z07",
+	"15:04",
+	"15:04Z07:00",
+}
+
+// TimeOfDay represents a time of day without date, with optional zone offset,
+// that may be null or not present in JSON at all.
+//
+// Data is always at January 1, year 0. Zone offset is only written when the location of Data is not UTC.
+type TimeOfDay struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid time of day
+	Data    time.Time
+}
+
+// NewTimeOfDay creates TimeOfDay from the clock and location of data.
+func NewTimeOfDay(data time.Time, presentValid ...bool) TimeOfDay {
+	d := TimeOfDay{
+		Present: true,
+		Valid:   true,
+		Data:    timeOfDayOf(data),
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+
+	return d
+}
+
+func NewTimeOfDayPtr(data time.Time, presentValid ...bool) *TimeOfDay {
+	d := NewTimeOfDay(data, presentValid...)
+	return &d
+}
+
+// ParseTimeOfDay parses `HH:MM[:SS[.fraction]][zone]` string s into a valid TimeOfDay.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := parseTimeOfDay(s)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return NewTimeOfDay(t), nil
+}
+
+func (d TimeOfDay) IsPresent() bool {
+	return d.Present
+}
+
+func (d TimeOfDay) IsValid() bool {
+	return d.Valid
+}
+
+func (d TimeOfDay) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d TimeOfDay) IsZero() bool {
+	return !d.Present
+}
+
+func (d TimeOfDay) Ptr() *time.Time {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+// String returns the time of day, e.g. `15:04:05` or `15:04:05+07:00`, or empty string if it is not valid.
+func (d TimeOfDay) String() string {
+	if !d.Valid {
+		return ""
+	}
+	if d.Data.Location() == time.UTC {
+		return d.Data.Format(timeOfDayLayout)
+	}
+	return d.Data.Format(timeOfDayZoneLayout)
+}
+
+var (
+	_ driver.Valuer                    = (*TimeOfDay)(nil)
+	_ sql.Scanner                      = (*TimeOfDay)(nil)
+	_ json.Marshaler                   = (*TimeOfDay)(nil)
+	_ json.Unmarshaler                 = (*TimeOfDay)(nil)
+	_ bson.ValueMarshaler              = (*TimeOfDay)(nil)
+	_ bson.ValueUnmarshaler            = (*TimeOfDay)(nil)
+	_ msgpack.Marshaler                = (*TimeOfDay)(nil)
+	_ msgpack.Unmarshaler              = (*TimeOfDay)(nil)
+	_ pgtype.TimeScanner               = (*TimeOfDay)(nil)
+	_ pgtype.TimeValuer                = (*TimeOfDay)(nil)
+	_ schema.QueryAppender             = (*TimeOfDay)(nil)
+	_ gormschema.GormDataTypeInterface = (*TimeOfDay)(nil)
+	_ migrator.GormDataTypeInterface   = (*TimeOfDay)(nil)
+)
+
+// Scan implements sql.Scanner interface
+func (d *TimeOfDay) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		t   time.Time
+		err error
+	)
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		t = v
+	case []byte:
+		t, err = parseTimeOfDay(string(v))
+	case string:
+		t, err = parseTimeOfDay(v)
+	default:
+		return errors.New(fmt.Sprint("Failed to scan time of day value:", value))
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Valid = true
+	d.Data = timeOfDayOf(t)
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (d TimeOfDay) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+// ScanTime implements pgtype.TimeScanner interface.
+func (d *TimeOfDay) ScanTime(v pgtype.Time) error {
+	d.Present = true
+	d.Valid = v.Valid
+	d.Data = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(v.Microseconds) * time.Microsecond)
+	return nil
+}
+
+// TimeValue implements pgtype.TimeValuer interface.
+//
+// The clock is written as is, as postgres `time` has no time zone.
+func (d TimeOfDay) TimeValue() (pgtype.Time, error) {
+	if !d.Valid {
+		return pgtype.Time{}, nil
+	}
+	t := d.Data
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	return pgtype.Time{Microseconds: int64(clock / time.Microsecond), Valid: true}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d TimeOfDay) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (TimeOfDay) GormDataType() string {
+	return "time"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (TimeOfDay) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return gormColumnType{Postgres: "time", MySQL: "time", SQLite: "text", SQLServer: "time"}.of(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d TimeOfDay) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+func (d *TimeOfDay) UnmarshalJSON(data []byte) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var timeString string
+	if err := json.Unmarshal(data, &timeString); err != nil {
+		return rejectError(data, "time of day", err)
+	}
+
+	t, err := parseTimeOfDay(timeString)
+	if err != nil {
+		return rejectError(data, "time of day", err)
+	}
+	d.Data = t
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// TimeOfDay is encoded as BSON string, as BSON has no time of day type.
+func (d TimeOfDay) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	t, byt, err := bson.MarshalValue(d.String())
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *TimeOfDay) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		raw = bson.RawValue{Type: bson.Type(t), Value: data}
+		err error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil
+	case bson.TypeString:
+		err = d.Scan(raw.StringValue())
+	case bson.TypeDateTime:
+		err = d.Scan(raw.Time().UTC())
+	default:
+		err = fmt.Errorf("nullable: unsupported bson type %s for time of day", raw.Type)
+	}
+	if err != nil {
+		return rejectError(data, "time of day", err)
+	}
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d TimeOfDay) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(d.String())
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *TimeOfDay) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val *string
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	return d.Scan(*val)
+}
+
+func (TimeOfDay) FiberConverter(value string) reflect.Value {
+	t, err := parseTimeOfDay(value)
+	if err != nil {
+		a := NewTimeOfDay(t, true, false)
+		return reflect.ValueOf(a)
+	}
+	a := NewTimeOfDay(t, true, true)
+	return reflect.ValueOf(a)
+}
+
+// timeOfDayOf returns the clock of t at January 1, year 0, in the zone offset of t.
+//
+// The offset is fixed, as a named location at year 0 has its historical offset, e.g. LMT +07:07 of Asia/Jakarta.
+func timeOfDayOf(t time.Time) time.Time {
+	loc := time.UTC
+	if t.Location() != time.UTC {
+		name, offset := t.Zone()
+		loc = time.FixedZone(name, offset)
+	}
+	return time.Date(0, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+func parseTimeOfDay(s string) (time.Time, error) {
+	for _, layout := range timeOfDayLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return timeOfDayOf(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("nullable: invalid time of day %q", s)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"encoding/json"
+)
+
+func TestTimeOfDay_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		buf    *bytes.Buffer
+		expect string
+		valid  bool
+	}{
+		{
+			name: "null value",
+			buf:  bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name:   "time value",
+			buf:    bytes.NewBufferString(`{"value":"15:04:05"}`),
+			expect: `"15:04:05"`,
+			valid:  true,
+		},
+		{
+			name:   "fractional value",
+			buf:    bytes.NewBufferString(`{"value":"15:04:05.123"}`),
+			expect: `"15:04:05.123"`,
+			valid:  true,
+		},
+		{
+			name:   "timetz value",
+			buf:    bytes.NewBufferString(`{"value":"15:04:05+07"}`),
+			expect: `"15:04:05+07:00"`,
+			valid:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := struct {
+				Value TimeOfDay `json:"value"`
+			}{}
+
+			if err := json.Unmarshal(tt.buf.Bytes(), &str); err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			got := str.Value
+			if !got.Present || got.Valid != tt.valid {
+				t.Fatalf("expected valid to be %v got %#v", tt.valid, got)
+			}
+			if !tt.valid {
+				return
+			}
+
+			byt, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+			if string(byt) != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestNewTimeOfDay_Location(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skipf("time zone database is not available: %s", err)
+	}
+
+	tests := []struct {
+		name   string
+		data   time.Time
+		expect string
+	}{
+		{name: "utc", data: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), expect: `"12:00:00"`},
+		{name: "fixed zone", data: time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("", 8*3600)), expect: `"12:00:00+08:00"`},
+		{name: "named location", data: time.Date(2024, 1, 1, 12, 0, 0, 0, jakarta), expect: `"12:00:00+07:00"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byt, err := json.Marshal(NewTimeOfDay(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+			if string(byt) != tt.expect {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
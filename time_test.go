@@ -0,0 +1,270 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type timeJsonTest struct {
+	Value Time `json:"value,omitzero"`
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	value := NewTime(time.Date(2024, 1, 2, 3, 4, 5, 678900000, time.UTC))
+
+	tests := []struct {
+		name      string
+		data      timeJsonTest
+		format    string
+		location  *time.Location
+		precision time.Duration
+		expect    *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   timeJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: timeJsonTest{
+				Value: Time{
+					Present: true,
+					Valid:   false,
+				},
+			},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name:   "default format",
+			data:   timeJsonTest{Value: value},
+			format: time.RFC3339Nano,
+			expect: bytes.NewBufferString(`{"value":"2024-01-02T03:04:05.6789Z"}`),
+		},
+		{
+			name:      "location and precision",
+			data:      timeJsonTest{Value: value},
+			format:    time.RFC3339Nano,
+			location:  jakarta,
+			precision: time.Second,
+			expect:    bytes.NewBufferString(`{"value":"2024-01-02T10:04:05+07:00"}`),
+		},
+		{
+			name:   "unix",
+			data:   timeJsonTest{Value: value},
+			format: TimeFormatUnix,
+			expect: bytes.NewBufferString(`{"value":1704164645}`),
+		},
+		{
+			name:   "unix milli",
+			data:   timeJsonTest{Value: value},
+			format: TimeFormatUnixMilli,
+			expect: bytes.NewBufferString(`{"value":1704164645678}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.format != "" {
+				TimeFormat = tt.format
+			}
+			TimeLocation = tt.location
+			TimePrecision = tt.precision
+			defer func() {
+				TimeFormat = time.RFC3339Nano
+				TimeLocation = nil
+				TimePrecision = 0
+			}()
+
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		buf     *bytes.Buffer
+		format  string
+		layouts []string
+		expect  time.Time
+		invalid bool
+	}{
+		{
+			name:   "any layout",
+			buf:    bytes.NewBufferString(`{"value":"2024-01-02 03:04:05"}`),
+			expect: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			name:    "strict layout",
+			buf:     bytes.NewBufferString(`{"value":"2024-01-02 03:04:05"}`),
+			layouts: []string{time.RFC3339},
+			invalid: true,
+		},
+		{
+			name:   "custom format",
+			buf:    bytes.NewBufferString(`{"value":"02/01/2024 03:04"}`),
+			format: "02/01/2006 15:04",
+			expect: time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+		{
+			name:    "custom format with strict layout",
+			buf:     bytes.NewBufferString(`{"value":"02/01/2024 03:04"}`),
+			format:  "02/01/2006 15:04",
+			layouts: []string{time.RFC3339},
+			expect:  time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+		{
+			name:   "unix milli",
+			buf:    bytes.NewBufferString(`{"value":1704164645678}`),
+			format: TimeFormatUnixMilli,
+			expect: time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.format != "" {
+				TimeFormat = tt.format
+			}
+			TimeInputLayouts = tt.layouts
+			defer func() {
+				TimeFormat = time.RFC3339Nano
+				TimeInputLayouts = nil
+			}()
+
+			str := struct {
+				Value Time `json:"value"`
+			}{}
+
+			err := json.Unmarshal(tt.buf.Bytes(), &str)
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("expected error for invalid layout")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			got := str.Value
+			if !got.Present || !got.Valid || !got.Data.Equal(tt.expect) {
+				t.Errorf("expected value to be %s got %#v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestTime_BSON(t *testing.T) {
+	type timeBsonTest struct {
+		Value     Time `bson:"value"`
+		Null      Time `bson:"null"`
+		Undefined Time `bson:"undefined,omitempty"`
+	}
+	expect := time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC)
+
+	byt, err := bson.Marshal(timeBsonTest{Value: NewTime(expect), Null: NewTime(time.Time{}, true, false)})
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	if typ := bson.Raw(byt).Lookup("value").Type; typ != bson.TypeDateTime {
+		t.Fatalf("expected bson type to be datetime got %s", typ)
+	}
+	if _, err := bson.Raw(byt).LookupErr("undefined"); err == nil {
+		t.Fatalf("expected undefined value to be omitted got %s", bson.Raw(byt))
+	}
+
+	var got timeBsonTest
+	if err := bson.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !got.Value.Valid || !got.Value.Data.Equal(expect) {
+		t.Errorf("expected value to be %s got %#v", expect, got.Value)
+	}
+	if !got.Null.Present || got.Null.Valid {
+		t.Errorf("expected null value got %#v", got.Null)
+	}
+
+	byt, err = bson.Marshal(bson.D{
+		{Key: "value", Value: "2024-01-02T03:04:05.678Z"},
+		{Key: "null", Value: bson.Timestamp{T: uint32(expect.Unix())}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	if err := bson.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if !got.Value.Data.Equal(expect) || !got.Null.Data.Equal(expect.Truncate(time.Second)) {
+		t.Errorf("expected value to be %s got %#v", expect, got)
+	}
+}
+
+func TestTime_UnmarshalBSONValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		format  string
+		layouts []string
+		expect  time.Time
+		invalid bool
+	}{
+		{name: "any layout", value: "2024-01-02 03:04:05", expect: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{name: "strict layout", value: "2024-01-02 03:04:05", layouts: []string{time.RFC3339}, invalid: true},
+		{name: "custom format", value: "02/01/2024 03:04", format: "02/01/2006 15:04", expect: time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.format != "" {
+				TimeFormat = tt.format
+			}
+			TimeInputLayouts = tt.layouts
+			defer func() {
+				TimeFormat = time.RFC3339Nano
+				TimeInputLayouts = nil
+			}()
+
+			typ, data, err := bson.MarshalValue(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			var got Time
+			err = got.UnmarshalBSONValue(byte(typ), data)
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("expected error for invalid layout")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if !got.Present || !got.Valid || !got.Data.Equal(tt.expect) {
+				t.Errorf("expected value to be %s got %#v", tt.expect, got)
+			}
+		})
+	}
+}
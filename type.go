@@ -9,6 +9,7 @@ package nullable
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
@@ -16,8 +17,14 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
 	"github.com/vmihailenco/msgpack/v5"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
 )
 
 // Type represents a custom struct that may be null or not
@@ -60,6 +67,12 @@ func (d Type[D]) GetValue() interface{} {
 	return d.Data
 }
 
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d Type[D]) IsZero() bool {
+	return !d.Present
+}
+
 func (d Type[D]) Ptr() *D {
 	if d.Valid {
 		return &d.Data
@@ -68,14 +81,18 @@ func (d Type[D]) Ptr() *D {
 }
 
 var (
-	_ driver.Valuer       = (*Type[any])(nil)
-	_ sql.Scanner         = (*Type[any])(nil)
-	_ json.Marshaler      = (*Type[any])(nil)
-	_ json.Unmarshaler    = (*Type[any])(nil)
-	_ bson.Marshaler      = (*Type[any])(nil)
-	_ bson.Unmarshaler    = (*Type[any])(nil)
-	_ msgpack.Marshaler   = (*Type[any])(nil)
-	_ msgpack.Unmarshaler = (*Type[any])(nil)
+	_ driver.Valuer                    = (*Type[any])(nil)
+	_ sql.Scanner                      = (*Type[any])(nil)
+	_ json.Marshaler                   = (*Type[any])(nil)
+	_ json.Unmarshaler                 = (*Type[any])(nil)
+	_ bson.ValueMarshaler              = (*Type[any])(nil)
+	_ bson.ValueUnmarshaler            = (*Type[any])(nil)
+	_ msgpack.Marshaler                = (*Type[any])(nil)
+	_ msgpack.Unmarshaler              = (*Type[any])(nil)
+	_ schema.QueryAppender             = (*Type[any])(nil)
+	_ gormschema.GormDataTypeInterface = (*Type[any])(nil)
+	_ migrator.GormDataTypeInterface   = (*Type[any])(nil)
+	_ gorm.Valuer                      = (*Type[any])(nil)
 )
 
 // Scan implements sql.Scanner interface
@@ -111,8 +128,47 @@ func (d Type[D]) Value() (driver.Value, error) {
 	return string(val), nil
 }
 
+// AppendQuery implements schema.QueryAppender interface.
+//
+// Type is written as JSON literal, casted to jsonb in postgres.
+func (d Type[D]) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	if !d.Valid {
+		return dialect.AppendNull(b), nil
+	}
+	data, err := json.Marshal(d.Data)
+	if err != nil {
+		return nil, err
+	}
+	b = gen.Dialect().AppendJSON(b, data)
+	if gen.Dialect().Name() == dialect.PG {
+		b = append(b, "::jsonb"...)
+	}
+	return b, nil
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (Type[D]) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (Type[D]) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	return gormColumnType{Postgres: "jsonb", MySQL: "JSON", SQLite: "JSON", SQLServer: "NVARCHAR(MAX)"}.of(db)
+}
+
+// GormValue implements gorm.Valuer interface.
+//
+// Type is casted to JSON in MySQL.
+func (d Type[D]) GormValue(_ context.Context, db *gorm.DB) clause.Expr {
+	if !d.Valid {
+		return gormNull
+	}
+	data, err := json.Marshal(d.Data)
+	return gormJSON(db, data, err)
+}
+
 // MarshalJSON implements json.Marshaler interface.
-// Bug: Marshal undefined value
+// Use `omitzero` json tag to omit undefined value.
 func (d Type[D]) MarshalJSON() ([]byte, error) {
 	if !d.Present {
 		return []byte(`null`), nil
@@ -130,33 +186,33 @@ func (d *Type[D]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 	if err := json.Unmarshal(data, &d.Data); err != nil {
-		return err
+		return rejectError(data, reflect.TypeFor[D]().String(), err)
 	}
 	d.Valid = true
 	return nil
 }
 
-// MarshalBSON implements bson.Marshaler interface.
-func (d Type[D]) MarshalBSON() (byt []byte, err error) {
-	var tmp *bool
-	_, byt, err = bson.MarshalValue(tmp)
-	if !d.Present {
-		return byt, err
-	} else if !d.Valid {
-		return byt, err
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d Type[D]) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
 	}
-	return bson.Marshal(d.Data)
+	t, byt, err := bson.MarshalValue(d.Data)
+	return byte(t), byt, err
 }
 
-// UnmarshalBSON implements bson.Marshaler interface.
-func (d *Type[D]) UnmarshalBSON(data []byte) error {
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *Type[D]) UnmarshalBSONValue(t byte, data []byte) error {
 	d.Present = true
+	d.Valid = false
 
-	if bytes.Equal(data, []byte("null")) {
+	raw := bson.RawValue{Type: bson.Type(t), Value: data}
+	if raw.Type == bson.TypeNull || raw.Type == bson.TypeUndefined {
 		return nil
 	}
-	if err := bson.Unmarshal(data, &d.Data); err != nil {
-		return err
+
+	if err := raw.Unmarshal(&d.Data); err != nil {
+		return rejectError(data, reflect.TypeFor[D]().String(), err)
 	}
 	d.Valid = true
 	return nil
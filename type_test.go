@@ -23,7 +23,7 @@ type testValue struct {
 }
 
 type typeJsonTest struct {
-	Value Type[testValue] `json:"value,omitempty"`
+	Value Type[testValue] `json:"value,omitzero"`
 }
 
 func TestType_MarshalJSON(t *testing.T) {
@@ -32,6 +32,11 @@ func TestType_MarshalJSON(t *testing.T) {
 		data   typeJsonTest
 		expect *bytes.Buffer
 	}{
+		{
+			name:   "undefined value",
+			data:   typeJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
 		{
 			name: "null value",
 			data: typeJsonTest{
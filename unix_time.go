@@ -0,0 +1,612 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/dromara/carbon/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+// UnixTime represents go time encoded as unix seconds that may be null or not
+// present in JSON at all.
+type UnixTime struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid time
+	Data    time.Time
+}
+
+func NewUnixTime(data time.Time, presentValid ...bool) UnixTime {
+	return UnixTime(newEpochTime(data, presentValid...))
+}
+
+func NewUnixTimePtr(data time.Time, presentValid ...bool) *UnixTime {
+	d := NewUnixTime(data, presentValid...)
+	return &d
+}
+
+func (d UnixTime) IsPresent() bool {
+	return d.Present
+}
+
+func (d UnixTime) IsValid() bool {
+	return d.Valid
+}
+
+func (d UnixTime) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d UnixTime) IsZero() bool {
+	return !d.Present
+}
+
+func (d UnixTime) Null() null.Time {
+	return epochTime(d).null()
+}
+
+func (d UnixTime) Ptr() *time.Time {
+	return epochTime(d).ptr()
+}
+
+// Unix returns the value as unix seconds.
+func (d UnixTime) Unix() int64 {
+	return epochOf(d.Data, false)
+}
+
+// Time converts the value to Time.
+func (d UnixTime) Time() Time {
+	return epochTime(d).toTime()
+}
+
+func (d UnixTime) Carbon() *carbon.Carbon {
+	return epochTime(d).toCarbon()
+}
+
+var (
+	_ driver.Valuer                    = (*UnixTime)(nil)
+	_ sql.Scanner                      = (*UnixTime)(nil)
+	_ json.Marshaler                   = (*UnixTime)(nil)
+	_ json.Unmarshaler                 = (*UnixTime)(nil)
+	_ bson.ValueMarshaler              = (*UnixTime)(nil)
+	_ bson.ValueUnmarshaler            = (*UnixTime)(nil)
+	_ msgpack.Marshaler                = (*UnixTime)(nil)
+	_ msgpack.Unmarshaler              = (*UnixTime)(nil)
+	_ pgtype.Int64Scanner              = (*UnixTime)(nil)
+	_ pgtype.Int64Valuer               = (*UnixTime)(nil)
+	_ pgtype.TimestamptzScanner        = (*UnixTime)(nil)
+	_ schema.QueryAppender             = (*UnixTime)(nil)
+	_ gormschema.GormDataTypeInterface = (*UnixTime)(nil)
+	_ migrator.GormDataTypeInterface   = (*UnixTime)(nil)
+)
+
+// Scan implements sql.Scanner interface
+//
+// Both integer and timestamp column are accepted.
+func (d *UnixTime) Scan(value interface{}) error {
+	return (*epochTime)(d).scan(value, false)
+}
+
+// Value implements driver.Valuer interface
+func (d UnixTime) Value() (driver.Value, error) {
+	return epochTime(d).value(false)
+}
+
+// ScanInt64 implements pgtype.Int64Scanner interface.
+func (d *UnixTime) ScanInt64(v pgtype.Int8) error {
+	return (*epochTime)(d).scanInt64(v, false)
+}
+
+// Int64Value implements pgtype.Int64Valuer interface.
+func (d UnixTime) Int64Value() (pgtype.Int8, error) {
+	return epochTime(d).int64Value(false)
+}
+
+// ScanTimestamptz implements pgtype.TimestamptzScanner interface.
+func (d *UnixTime) ScanTimestamptz(v pgtype.Timestamptz) error {
+	return (*epochTime)(d).scanTimestamptz(v)
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d UnixTime) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (UnixTime) GormDataType() string {
+	return string(gormschema.Int)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (UnixTime) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return gormSizedDataType(db, field, 64)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d UnixTime) MarshalJSON() ([]byte, error) {
+	return epochTime(d).marshalJSON(false)
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both JSON number and numeric JSON string are accepted. Fraction of a second is truncated.
+func (d *UnixTime) UnmarshalJSON(data []byte) error {
+	return (*epochTime)(d).unmarshalJSON(data, false)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d UnixTime) MarshalBSONValue() (byte, []byte, error) {
+	return epochTime(d).marshalBSONValue(false)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *UnixTime) UnmarshalBSONValue(t byte, data []byte) error {
+	return (*epochTime)(d).unmarshalBSONValue(t, data, false)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d UnixTime) MarshalMsgpack() ([]byte, error) {
+	return epochTime(d).marshalMsgpack(false)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *UnixTime) UnmarshalMsgpack(data []byte) error {
+	return (*epochTime)(d).unmarshalMsgpack(data, false)
+}
+
+func (UnixTime) FiberConverter(value string) reflect.Value {
+	return reflect.ValueOf(UnixTime(epochTimeFromString(value, false)))
+}
+
+// UnixMilliTime represents go time encoded as unix milliseconds that may be null or not
+// present in JSON at all.
+type UnixMilliTime struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid time
+	Data    time.Time
+}
+
+func NewUnixMilliTime(data time.Time, presentValid ...bool) UnixMilliTime {
+	return UnixMilliTime(newEpochTime(data, presentValid...))
+}
+
+func NewUnixMilliTimePtr(data time.Time, presentValid ...bool) *UnixMilliTime {
+	d := NewUnixMilliTime(data, presentValid...)
+	return &d
+}
+
+func (d UnixMilliTime) IsPresent() bool {
+	return d.Present
+}
+
+func (d UnixMilliTime) IsValid() bool {
+	return d.Valid
+}
+
+func (d UnixMilliTime) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d UnixMilliTime) IsZero() bool {
+	return !d.Present
+}
+
+func (d UnixMilliTime) Null() null.Time {
+	return epochTime(d).null()
+}
+
+func (d UnixMilliTime) Ptr() *time.Time {
+	return epochTime(d).ptr()
+}
+
+// UnixMilli returns the value as unix milliseconds.
+func (d UnixMilliTime) UnixMilli() int64 {
+	return epochOf(d.Data, true)
+}
+
+// Time converts the value to Time.
+func (d UnixMilliTime) Time() Time {
+	return epochTime(d).toTime()
+}
+
+func (d UnixMilliTime) Carbon() *carbon.Carbon {
+	return epochTime(d).toCarbon()
+}
+
+var (
+	_ driver.Valuer                    = (*UnixMilliTime)(nil)
+	_ sql.Scanner                      = (*UnixMilliTime)(nil)
+	_ json.Marshaler                   = (*UnixMilliTime)(nil)
+	_ json.Unmarshaler                 = (*UnixMilliTime)(nil)
+	_ bson.ValueMarshaler              = (*UnixMilliTime)(nil)
+	_ bson.ValueUnmarshaler            = (*UnixMilliTime)(nil)
+	_ msgpack.Marshaler                = (*UnixMilliTime)(nil)
+	_ msgpack.Unmarshaler              = (*UnixMilliTime)(nil)
+	_ pgtype.Int64Scanner              = (*UnixMilliTime)(nil)
+	_ pgtype.Int64Valuer               = (*UnixMilliTime)(nil)
+	_ pgtype.TimestamptzScanner        = (*UnixMilliTime)(nil)
+	_ schema.QueryAppender             = (*UnixMilliTime)(nil)
+	_ gormschema.GormDataTypeInterface = (*UnixMilliTime)(nil)
+	_ migrator.GormDataTypeInterface   = (*UnixMilliTime)(nil)
+)
+
+// Scan implements sql.Scanner interface
+//
+// Both integer and timestamp column are accepted.
+func (d *UnixMilliTime) Scan(value interface{}) error {
+	return (*epochTime)(d).scan(value, true)
+}
+
+// Value implements driver.Valuer interface
+func (d UnixMilliTime) Value() (driver.Value, error) {
+	return epochTime(d).value(true)
+}
+
+// ScanInt64 implements pgtype.Int64Scanner interface.
+func (d *UnixMilliTime) ScanInt64(v pgtype.Int8) error {
+	return (*epochTime)(d).scanInt64(v, true)
+}
+
+// Int64Value implements pgtype.Int64Valuer interface.
+func (d UnixMilliTime) Int64Value() (pgtype.Int8, error) {
+	return epochTime(d).int64Value(true)
+}
+
+// ScanTimestamptz implements pgtype.TimestamptzScanner interface.
+func (d *UnixMilliTime) ScanTimestamptz(v pgtype.Timestamptz) error {
+	return (*epochTime)(d).scanTimestamptz(v)
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d UnixMilliTime) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (UnixMilliTime) GormDataType() string {
+	return string(gormschema.Int)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (UnixMilliTime) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return gormSizedDataType(db, field, 64)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d UnixMilliTime) MarshalJSON() ([]byte, error) {
+	return epochTime(d).marshalJSON(true)
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Both JSON number and numeric JSON string are accepted. Fraction of a millisecond is truncated.
+func (d *UnixMilliTime) UnmarshalJSON(data []byte) error {
+	return (*epochTime)(d).unmarshalJSON(data, true)
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+func (d UnixMilliTime) MarshalBSONValue() (byte, []byte, error) {
+	return epochTime(d).marshalBSONValue(true)
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *UnixMilliTime) UnmarshalBSONValue(t byte, data []byte) error {
+	return (*epochTime)(d).unmarshalBSONValue(t, data, true)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d UnixMilliTime) MarshalMsgpack() ([]byte, error) {
+	return epochTime(d).marshalMsgpack(true)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *UnixMilliTime) UnmarshalMsgpack(data []byte) error {
+	return (*epochTime)(d).unmarshalMsgpack(data, true)
+}
+
+func (UnixMilliTime) FiberConverter(value string) reflect.Value {
+	return reflect.ValueOf(UnixMilliTime(epochTimeFromString(value, true)))
+}
+
+// epochTime is the implementation shared by UnixTime and UnixMilliTime,
+// which have the same underlying type and are converted to it.
+// milli selects unix milliseconds instead of unix seconds.
+type epochTime struct {
+	Present bool
+	Valid   bool
+	Data    time.Time
+}
+
+func newEpochTime(data time.Time, presentValid ...bool) epochTime {
+	d := epochTime{
+		Present: true,
+		Valid:   true,
+		Data:    data,
+	}
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+
+	return d
+}
+
+// epochTimeFromString parses unix time of a query or form value. Invalid value is null.
+func epochTimeFromString(value string, milli bool) epochTime {
+	n, err := parseEpoch(value)
+	if err != nil {
+		return newEpochTime(time.Time{}, true, false)
+	}
+	return newEpochTime(fromEpoch(n, milli), true, true)
+}
+
+func (d epochTime) null() null.Time {
+	return null.NewTime(d.Data, d.Present && d.Valid)
+}
+
+func (d epochTime) ptr() *time.Time {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+func (d epochTime) toTime() Time {
+	t := NewTime(d.Data, d.Present, d.Valid)
+	if d.Valid {
+		t.carbon = carbon.CreateFromStdTime(d.Data)
+	}
+	return t
+}
+
+func (d epochTime) toCarbon() *carbon.Carbon {
+	if !d.Valid {
+		return nil
+	}
+	return carbon.CreateFromStdTime(d.Data)
+}
+
+func (d *epochTime) scan(value interface{}, milli bool) error {
+	d.Present = true
+
+	t, valid, err := scanEpoch(value, milli)
+	if err != nil {
+		return err
+	}
+	d.Valid = valid
+	d.Data = t
+	return nil
+}
+
+func (d epochTime) value(milli bool) (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return epochOf(d.Data, milli), nil
+}
+
+func (d *epochTime) scanInt64(v pgtype.Int8, milli bool) error {
+	d.Present = true
+	d.Valid = v.Valid
+	d.Data = time.Time{}
+	if v.Valid {
+		d.Data = fromEpoch(v.Int64, milli)
+	}
+	return nil
+}
+
+func (d epochTime) int64Value(milli bool) (pgtype.Int8, error) {
+	if !d.Valid {
+		return pgtype.Int8{}, nil
+	}
+	return pgtype.Int8{Int64: epochOf(d.Data, milli), Valid: true}, nil
+}
+
+// scanTimestamptz converts postgres timestamptz into time.
+// Infinite timestamp is rejected, as it has no unix time representation.
+func (d *epochTime) scanTimestamptz(v pgtype.Timestamptz) error {
+	d.Present = true
+	d.Valid = false
+
+	if !v.Valid {
+		return nil
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("nullable: cannot scan %s timestamp", v.InfinityModifier)
+	}
+	d.Valid = true
+	d.Data = v.Time
+	return nil
+}
+
+func (d epochTime) marshalJSON(milli bool) ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, epochOf(d.Data, milli), 10), nil
+}
+
+func (d *epochTime) unmarshalJSON(data []byte, milli bool) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	raw := data
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return rejectError(data, epochKind(milli), err)
+		}
+		raw = []byte(s)
+	}
+	n, err := parseEpoch(string(raw))
+	if err != nil {
+		return rejectError(data, epochKind(milli), err)
+	}
+	d.Data = fromEpoch(n, milli)
+	d.Valid = true
+	return nil
+}
+
+func (d epochTime) marshalBSONValue(milli bool) (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	t, byt, err := bson.MarshalValue(epochOf(d.Data, milli))
+	return byte(t), byt, err
+}
+
+func (d *epochTime) unmarshalBSONValue(t byte, data []byte, milli bool) error {
+	d.Present = true
+
+	var (
+		raw   = bson.RawValue{Type: bson.Type(t), Value: data}
+		tm    time.Time
+		valid = true
+		err   error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		valid = false
+	case bson.TypeInt32, bson.TypeInt64:
+		tm = fromEpoch(raw.AsInt64(), milli)
+	case bson.TypeDouble:
+		tm = fromEpoch(int64(raw.Double()), milli)
+	case bson.TypeDateTime:
+		tm = raw.Time().UTC()
+	case bson.TypeString:
+		tm, valid, err = scanEpoch(raw.StringValue(), milli)
+	default:
+		err = fmt.Errorf("nullable: unsupported bson type %s for unix time", raw.Type)
+	}
+	if err != nil {
+		return rejectError(data, epochKind(milli), err)
+	}
+	d.Valid = valid
+	d.Data = tm
+	return nil
+}
+
+func (d epochTime) marshalMsgpack(milli bool) ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(epochOf(d.Data, milli))
+}
+
+func (d *epochTime) unmarshalMsgpack(data []byte, milli bool) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val *int64
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	d.Valid = true
+	d.Data = fromEpoch(*val, milli)
+	return nil
+}
+
+// epochKind returns the kind of DecodeError of unix time.
+func epochKind(milli bool) string {
+	if milli {
+		return "unix milli time"
+	}
+	return "unix time"
+}
+
+// epochOf returns t as unix seconds, or unix milliseconds if milli is true.
+func epochOf(t time.Time, milli bool) int64 {
+	if milli {
+		return t.UnixMilli()
+	}
+	return t.Unix()
+}
+
+// fromEpoch returns UTC time of unix seconds, or unix milliseconds if milli is true.
+func fromEpoch(n int64, milli bool) time.Time {
+	if milli {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
+
+// parseEpoch parses unix time number, e.g. `1700000000`, `1700000000.5` or `1.7e9`.
+// Fraction is truncated.
+func parseEpoch(s string) (int64, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("nullable: invalid unix time %s", s)
+	}
+	return int64(f), nil
+}
+
+// scanEpoch converts integer, timestamp or numeric text column into time.
+func scanEpoch(value interface{}, milli bool) (time.Time, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case int64:
+		return fromEpoch(v, milli), true, nil
+	case float64:
+		return fromEpoch(int64(v), milli), true, nil
+	case time.Time:
+		return v, true, nil
+	case []byte:
+		return scanEpoch(string(v), milli)
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return fromEpoch(n, milli), true, nil
+		}
+		c := carbon.Parse(v)
+		if !c.IsValid() {
+			return time.Time{}, false, errors.New("invalid date string")
+		}
+		return c.StdTime(), true, nil
+	default:
+		return time.Time{}, false, errors.New(fmt.Sprint("Failed to scan unix time value:", value))
+	}
+}
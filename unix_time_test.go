@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"encoding/json"
+)
+
+type unixTimeJsonTest struct {
+	Seconds UnixTime      `json:"seconds,omitzero"`
+	Millis  UnixMilliTime `json:"millis,omitzero"`
+}
+
+func TestUnixTime_MarshalJSON(t *testing.T) {
+	value := time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC)
+	tests := []struct {
+		name   string
+		data   unixTimeJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   unixTimeJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: unixTimeJsonTest{
+				Seconds: UnixTime{Present: true},
+				Millis:  UnixMilliTime{Present: true},
+			},
+			expect: bytes.NewBufferString(`{"seconds":null,"millis":null}`),
+		},
+		{
+			name: "valid value",
+			data: unixTimeJsonTest{
+				Seconds: NewUnixTime(value),
+				Millis:  NewUnixMilliTime(value),
+			},
+			expect: bytes.NewBufferString(`{"seconds":1704164645,"millis":1704164645678}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestUnixTime_UnmarshalJSON(t *testing.T) {
+	var got unixTimeJsonTest
+	if err := json.Unmarshal([]byte(`{"seconds":1704164645,"millis":"1704164645678"}`), &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+
+	if expect := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !got.Seconds.Valid || !got.Seconds.Data.Equal(expect) {
+		t.Errorf("expected value to be %s got %#v", expect, got.Seconds)
+	}
+	if expect := time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC); !got.Millis.Valid || !got.Millis.Data.Equal(expect) {
+		t.Errorf("expected value to be %s got %#v", expect, got.Millis)
+	}
+	if got.Millis.Time().Carbon() == nil {
+		t.Errorf("expected carbon of converted time")
+	}
+}
+
+func TestUnixTime_UnmarshalJSONNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		buf          string
+		expect       time.Time
+		expectMillis time.Time
+		invalid      bool
+	}{
+		{
+			name:         "fraction",
+			buf:          `{"seconds":1704164645.9,"millis":1704164645678.9}`,
+			expect:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			expectMillis: time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC),
+		},
+		{
+			name:         "exponent",
+			buf:          `{"seconds":1.7e9,"millis":"1.7e12"}`,
+			expect:       time.Unix(1.7e9, 0).UTC(),
+			expectMillis: time.UnixMilli(1.7e12).UTC(),
+		},
+		{
+			name:    "overflow",
+			buf:     `{"seconds":1e19}`,
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got unixTimeJsonTest
+			err := json.Unmarshal([]byte(tt.buf), &got)
+			if tt.invalid {
+				if err == nil {
+					t.Errorf("expected unmarshaling error got %#v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+			if !got.Seconds.Valid || !got.Seconds.Data.Equal(tt.expect) {
+				t.Errorf("expected value to be %s got %#v", tt.expect, got.Seconds)
+			}
+			if !got.Millis.Valid || !got.Millis.Data.Equal(tt.expectMillis) {
+				t.Errorf("expected value to be %s got %#v", tt.expectMillis, got.Millis)
+			}
+		})
+	}
+}
+
+func TestUnixTime_Scan(t *testing.T) {
+	expect := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, value := range []interface{}{int64(1704164645), expect, []byte("1704164645")} {
+		var got UnixTime
+		if err := got.Scan(value); err != nil {
+			t.Fatalf("unexpected scan error: %s", err)
+		}
+		if !got.Valid || !got.Data.Equal(expect) {
+			t.Errorf("expected value to be %s got %#v", expect, got)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Dialect is the SQL dialect used to build query.
+type Dialect int
+
+const (
+	DialectPostgres Dialect = iota // DialectPostgres uses `$n` placeholder and `"column"` quote
+	DialectMySQL                   // DialectMySQL uses `?` placeholder and "`column`" quote
+	DialectSQLite                  // DialectSQLite uses `?` placeholder and `"column"` quote
+)
+
+// Placeholder returns the n-th (1-based) bind placeholder of the dialect.
+func (d Dialect) Placeholder(n int) string {
+	if d == DialectPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// Quote quotes ident as a column identifier of the dialect.
+func (d Dialect) Quote(ident string) string {
+	if d == DialectMySQL {
+		return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+// UpdateSet is the SET clause of UPDATE query built from a struct of nullable fields.
+type UpdateSet struct {
+	Columns []string // Columns is the list of present columns, in struct order
+	Args    []any    // Args is the list of arguments, in the same order as the placeholders in Clause
+	Clause  string   // Clause is the SET expression, e.g. `"name" = $1, "age" = NULL`
+}
+
+// IsEmpty reports whether there is no present column to update.
+func (u UpdateSet) IsEmpty() bool {
+	return len(u.Columns) == 0
+}
+
+// BuildUpdateSet builds the SET clause of UPDATE query from v, a struct (or pointer to struct) of nullable fields.
+//
+// Only present fields are included. Present and valid fields are bound as argument,
+// present but not valid fields are written as NULL.
+// The column name is taken from `db` tag, then `bun` tag, then the snake_case of the field name.
+// Fields tagged with `-` are skipped.
+//
+// Placeholder of the following clause (e.g. WHERE) for postgres must start at len(Args)+1.
+//
+//	set, err := nullable.BuildUpdateSet(req, nullable.DialectPostgres)
+//	query := "UPDATE users SET " + set.Clause + " WHERE id = $" + strconv.Itoa(len(set.Args)+1)
+//	db.Exec(query, append(set.Args, id)...)
+func BuildUpdateSet(v any, dialect Dialect) (UpdateSet, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return UpdateSet{}, errors.New("nullable: update value must be a struct")
+	}
+
+	var (
+		set   UpdateSet
+		parts []string
+	)
+	for _, f := range nullableFields(rv, "db") {
+		if !f.Nullable.IsPresent() {
+			continue
+		}
+		column, skip := columnName(f)
+		if skip {
+			continue
+		}
+
+		set.Columns = append(set.Columns, column)
+		if !f.Nullable.IsValid() {
+			parts = append(parts, dialect.Quote(column)+" = NULL")
+			continue
+		}
+		set.Args = append(set.Args, f.Value.Interface())
+		parts = append(parts, dialect.Quote(column)+" = "+dialect.Placeholder(len(set.Args)))
+	}
+	set.Clause = strings.Join(parts, ", ")
+	return set, nil
+}
+
+// columnName returns the database column name of f, from `db` tag, `bun` tag or the snake_case field name.
+func columnName(f nullableField) (string, bool) {
+	for _, key := range []string{"db", "bun"} {
+		name, skip := tagName(f.Field, key)
+		if skip {
+			return "", true
+		}
+		if name != "" {
+			return name, false
+		}
+	}
+	return toSnakeCase(f.Name), false
+}
+
+// toSnakeCase converts go field name to snake_case, e.g. UserID to user_id.
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
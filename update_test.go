@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"reflect"
+	"testing"
+)
+
+type updateTest struct {
+	Name     String      `db:"name"`
+	Age      Int         `bun:"age,nullzero"`
+	UserID   Int         `json:"user_id"`
+	Tags     StringArray `db:"tags"`
+	Ignored  String      `db:"-"`
+	Untagged Bool
+}
+
+func TestBuildUpdateSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    updateTest
+		dialect Dialect
+		columns []string
+		args    []any
+		clause  string
+	}{
+		{
+			name:    "undefined",
+			data:    updateTest{},
+			dialect: DialectPostgres,
+			clause:  "",
+		},
+		{
+			name: "postgres",
+			data: updateTest{
+				Name:     NewString("name"),
+				Age:      NewInt(0, true, false),
+				UserID:   NewInt(5),
+				Ignored:  NewString("ignored"),
+				Untagged: NewBool(true),
+			},
+			dialect: DialectPostgres,
+			columns: []string{"name", "age", "user_id", "untagged"},
+			args:    []any{NewString("name"), NewInt(5), NewBool(true)},
+			clause:  `"name" = $1, "age" = NULL, "user_id" = $2, "untagged" = $3`,
+		},
+		{
+			name: "mysql",
+			data: updateTest{
+				Name: NewString("", true, false),
+				Age:  NewInt(20),
+			},
+			dialect: DialectMySQL,
+			columns: []string{"name", "age"},
+			args:    []any{NewInt(20)},
+			clause:  "`name` = NULL, `age` = ?",
+		},
+		{
+			name: "sqlite",
+			data: updateTest{
+				Age: NewInt(20),
+			},
+			dialect: DialectSQLite,
+			columns: []string{"age"},
+			args:    []any{NewInt(20)},
+			clause:  `"age" = ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildUpdateSet(&tt.data, tt.dialect)
+			if err != nil {
+				t.Fatalf("unexpected build error: %s", err)
+			}
+			if got.Clause != tt.clause {
+				t.Errorf("expected clause to be %s got %s", tt.clause, got.Clause)
+			}
+			if !reflect.DeepEqual(got.Columns, tt.columns) || !reflect.DeepEqual(got.Args, tt.args) {
+				t.Errorf("expected columns %v and args %v got %v and %v", tt.columns, tt.args, got.Columns, got.Args)
+			}
+		})
+	}
+}
+
+type nullableInterfaceTest struct {
+	Name  String   `db:"name" json:"name" bson:"name" bun:"name" gorm:"column:name"`
+	Value Nullable `db:"value" json:"value" bson:"value" bun:"value" gorm:"column:value"`
+}
+
+func TestNullableFields_NilInterface(t *testing.T) {
+	var nilString *String
+	tests := []struct {
+		name  string
+		value Nullable
+		cols  []string
+	}{
+		{name: "nil interface", value: nil, cols: []string{"name"}},
+		{name: "nil pointer", value: nilString, cols: []string{"name"}},
+		{name: "value", value: NewInt(5), cols: []string{"name", "value"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := nullableInterfaceTest{Name: NewString("name"), Value: tt.value}
+
+			set, err := BuildUpdateSet(&data, DialectPostgres)
+			if err != nil {
+				t.Fatalf("unexpected build error: %s", err)
+			}
+			if !reflect.DeepEqual(set.Columns, tt.cols) {
+				t.Errorf("expected columns to be %v got %v", tt.cols, set.Columns)
+			}
+			if cols, err := BunColumns(&data); err != nil || !reflect.DeepEqual(cols, tt.cols) {
+				t.Errorf("expected bun columns to be %v got %v (%v)", tt.cols, cols, err)
+			}
+			if updates, err := GormUpdates(&data); err != nil || len(updates) != len(tt.cols) {
+				t.Errorf("expected %d gorm updates got %v (%v)", len(tt.cols), updates, err)
+			}
+			if _, err := BuildMongoUpdate(&data, MongoNullSet); err != nil {
+				t.Errorf("unexpected mongo update error: %s", err)
+			}
+			if _, err := Diff(&nullableInterfaceTest{}, &data); err != nil {
+				t.Errorf("unexpected diff error: %s", err)
+			}
+			if err := ApplyPatch(&nullableInterfaceTest{}, &data); err != nil {
+				t.Errorf("unexpected patch error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/uptrace/bun/schema"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	gormschema "gorm.io/gorm/schema"
+
+	"encoding/json"
+)
+
+// UUIDValueBinary controls the driver.Valuer output of UUID.
+// When false (the default), UUID is written as canonical text, for postgres `uuid` column.
+// When true, UUID is written as 16 bytes, for MySQL `BINARY(16)` column.
+//
+// It should be set once, before any query takes place.
+var UUIDValueBinary = false
+
+// UUID represents a uuid that may be null or not
+// present in JSON at all.
+type UUID struct {
+	Present bool // Present is true if key is present in JSON
+	Valid   bool // Valid is true if value is not null and valid uuid
+	Data    uuid.UUID
+}
+
+func NewUUID(data uuid.UUID, presentValid ...bool) UUID {
+	d := UUID{
+		Present: true,
+		Valid:   true,
+		Data:    data,
+	}
+
+	if len(presentValid) > 0 {
+		d.Present = presentValid[0]
+		d.Valid = false
+		if len(presentValid) > 1 {
+			d.Valid = presentValid[1]
+		}
+	}
+	return d
+}
+
+func NewUUIDPtr(data uuid.UUID, presentValid ...bool) *UUID {
+	d := NewUUID(data, presentValid...)
+	return &d
+}
+
+// ParseUUID parses uuid string s into a valid UUID.
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return UUID{}, err
+	}
+	return NewUUID(u), nil
+}
+
+func (d UUID) IsPresent() bool {
+	return d.Present
+}
+
+func (d UUID) IsValid() bool {
+	return d.Valid
+}
+
+func (d UUID) GetValue() interface{} {
+	return d.Data
+}
+
+// IsZero reports whether the value is not present.
+// It allows the value to be omitted with the `omitzero` json tag.
+func (d UUID) IsZero() bool {
+	return !d.Present
+}
+
+func (d UUID) Ptr() *uuid.UUID {
+	if d.Valid {
+		return &d.Data
+	}
+	return nil
+}
+
+var (
+	_ driver.Valuer                    = (*UUID)(nil)
+	_ sql.Scanner                      = (*UUID)(nil)
+	_ json.Marshaler                   = (*UUID)(nil)
+	_ json.Unmarshaler                 = (*UUID)(nil)
+	_ bson.ValueMarshaler              = (*UUID)(nil)
+	_ bson.ValueUnmarshaler            = (*UUID)(nil)
+	_ msgpack.Marshaler                = (*UUID)(nil)
+	_ msgpack.Unmarshaler              = (*UUID)(nil)
+	_ pgtype.UUIDScanner               = (*UUID)(nil)
+	_ pgtype.UUIDValuer                = (*UUID)(nil)
+	_ schema.QueryAppender             = (*UUID)(nil)
+	_ gormschema.GormDataTypeInterface = (*UUID)(nil)
+	_ migrator.GormDataTypeInterface   = (*UUID)(nil)
+)
+
+// Scan implements sql.Scanner interface
+//
+// Both 16 bytes binary and text uuid are accepted.
+func (d *UUID) Scan(value interface{}) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		u   uuid.UUID
+		err error
+	)
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if len(v) == 16 {
+			u, err = uuid.FromBytes(v)
+		} else {
+			u, err = uuid.ParseBytes(v)
+		}
+	case string:
+		u, err = uuid.Parse(v)
+	default:
+		return errors.New(fmt.Sprint("Failed to scan uuid value:", value))
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Valid = true
+	d.Data = u
+	return nil
+}
+
+// Value implements driver.Valuer interface
+func (d UUID) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	if UUIDValueBinary {
+		b := make([]byte, 16)
+		copy(b, d.Data[:])
+		return b, nil
+	}
+	return d.Data.String(), nil
+}
+
+// ScanUUID implements pgtype.UUIDScanner interface.
+func (d *UUID) ScanUUID(v pgtype.UUID) error {
+	d.Present = true
+	d.Valid = v.Valid
+	d.Data = v.Bytes
+	return nil
+}
+
+// UUIDValue implements pgtype.UUIDValuer interface.
+func (d UUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: d.Data, Valid: d.Valid}, nil
+}
+
+// AppendQuery implements schema.QueryAppender interface.
+func (d UUID) AppendQuery(gen schema.QueryGen, b []byte) ([]byte, error) {
+	return appendQueryValue(gen, b, d)
+}
+
+// GormDataType implements schema.GormDataTypeInterface interface.
+func (UUID) GormDataType() string {
+	return "uuid"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface interface.
+func (UUID) GormDBDataType(db *gorm.DB, _ *gormschema.Field) string {
+	if UUIDValueBinary {
+		return gormColumnType{Postgres: "uuid", MySQL: "binary(16)", SQLite: "blob", SQLServer: "binary(16)"}.of(db)
+	}
+	return gormColumnType{Postgres: "uuid", MySQL: "char(36)", SQLite: "text", SQLServer: "uniqueidentifier"}.of(db)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (d UUID) MarshalJSON() ([]byte, error) {
+	if !d.Present {
+		return []byte(`null`), nil
+	} else if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Data.String())
+}
+
+// UnmarshalJSON implements json.Marshaler interface.
+//
+// Malformed uuid is always rejected.
+func (d *UUID) UnmarshalJSON(data []byte) error {
+	d.Present = true
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return rejectError(data, "uuid", err)
+	}
+
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return rejectError(data, "uuid", err)
+	}
+
+	d.Data = u
+	d.Valid = true
+	return nil
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler interface.
+//
+// UUID is encoded as binary subtype 4.
+func (d UUID) MarshalBSONValue() (byte, []byte, error) {
+	if !d.Present || !d.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	t, byt, err := bson.MarshalValue(bson.Binary{Subtype: bson.TypeBinaryUUID, Data: d.Data[:]})
+	return byte(t), byt, err
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler interface.
+func (d *UUID) UnmarshalBSONValue(t byte, data []byte) error {
+	d.Present = true
+	d.Valid = false
+
+	var (
+		raw = bson.RawValue{Type: bson.Type(t), Value: data}
+		u   uuid.UUID
+		err error
+	)
+	switch raw.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		return nil
+	case bson.TypeBinary:
+		subtype, b := raw.Binary()
+		if subtype != bson.TypeBinaryUUID && subtype != bson.TypeBinaryUUIDOld {
+			err = fmt.Errorf("nullable: invalid uuid binary subtype %d", subtype)
+			break
+		}
+		u, err = uuid.FromBytes(b)
+	case bson.TypeString:
+		u, err = uuid.Parse(raw.StringValue())
+	default:
+		err = fmt.Errorf("nullable: unsupported bson type %s for uuid", raw.Type)
+	}
+	if err != nil {
+		return rejectError(data, "uuid", err)
+	}
+
+	d.Data = u
+	d.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface.
+func (d UUID) MarshalMsgpack() ([]byte, error) {
+	if !d.Present || !d.Valid {
+		return msgpack.Marshal(nil)
+	}
+	return msgpack.Marshal(d.Data.String())
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface.
+func (d *UUID) UnmarshalMsgpack(data []byte) error {
+	d.Present = true // Jika fungsi ini dipanggil, berarti key-nya ada di payload
+
+	var val interface{}
+	if err := msgpack.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	if val == nil {
+		d.Valid = false
+		return nil
+	}
+
+	return d.Scan(val)
+}
+
+func (UUID) FiberConverter(value string) reflect.Value {
+	u, err := uuid.Parse(value)
+	if err != nil {
+		a := NewUUID(uuid.Nil, true, false)
+		return reflect.ValueOf(a)
+	}
+	a := NewUUID(u, true, true)
+	return reflect.ValueOf(a)
+}
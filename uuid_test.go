@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) Portalnesia - All Rights Reserved
+ * Unauthorized copying of this file, via any medium is strictly prohibited
+ * Proprietary and confidential
+ * Written by Putu Aditya <aditya@portalnesia.com>
+ */
+
+package nullable
+
+import (
+	"bytes"
+	"testing"
+
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var testUUID = uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+type uuidJsonTest struct {
+	Value UUID `json:"value,omitzero" bson:"value,omitempty"`
+}
+
+func TestUUID_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   uuidJsonTest
+		expect *bytes.Buffer
+	}{
+		{
+			name:   "undefined value",
+			data:   uuidJsonTest{},
+			expect: bytes.NewBufferString(`{}`),
+		},
+		{
+			name: "null value",
+			data: uuidJsonTest{
+				Value: UUID{
+					Present: true,
+					Valid:   false,
+				},
+			},
+			expect: bytes.NewBufferString(`{"value":null}`),
+		},
+		{
+			name: "valid value",
+			data: uuidJsonTest{
+				Value: NewUUID(testUUID),
+			},
+			expect: bytes.NewBufferString(`{"value":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var byt []byte
+			var err error
+
+			if byt, err = json.Marshal(tt.data); err != nil {
+				t.Fatalf("unexpected marshaling error: %s", err)
+			}
+
+			if !bytes.Equal(byt, tt.expect.Bytes()) {
+				t.Errorf("expected value to be %s got %s", tt.expect, byt)
+			}
+		})
+	}
+}
+
+func TestUUID_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		buf     *bytes.Buffer
+		expect  UUID
+		invalid bool
+	}{
+		{
+			name: "null value",
+			buf:  bytes.NewBufferString(`{"value":null}`),
+			expect: UUID{
+				Present: true,
+			},
+		},
+		{
+			name:   "valid value",
+			buf:    bytes.NewBufferString(`{"value":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`),
+			expect: NewUUID(testUUID),
+		},
+		{
+			name:    "malformed value",
+			buf:     bytes.NewBufferString(`{"value":"6ba7b810-9dad"}`),
+			invalid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := struct {
+				Value UUID `json:"value"`
+			}{}
+
+			err := json.Unmarshal(tt.buf.Bytes(), &str)
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("expected error for malformed uuid")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected unmarshaling error: %s", err)
+			}
+
+			if str.Value != tt.expect {
+				t.Errorf("expected value to be %#v got %#v", tt.expect, str.Value)
+			}
+		})
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	for _, value := range []interface{}{testUUID[:], testUUID.String(), []byte(testUUID.String())} {
+		var got UUID
+		if err := got.Scan(value); err != nil {
+			t.Fatalf("unexpected scan error: %s", err)
+		}
+		if got != NewUUID(testUUID) {
+			t.Errorf("expected value to be %s got %#v", testUUID, got)
+		}
+	}
+}
+
+func TestUUID_BSON(t *testing.T) {
+	byt, err := bson.Marshal(uuidJsonTest{Value: NewUUID(testUUID)})
+	if err != nil {
+		t.Fatalf("unexpected marshaling error: %s", err)
+	}
+	if subtype, _, ok := bson.Raw(byt).Lookup("value").BinaryOK(); !ok || subtype != bson.TypeBinaryUUID {
+		t.Fatalf("expected bson binary subtype 4 got %s", bson.Raw(byt))
+	}
+
+	var got uuidJsonTest
+	if err := bson.Unmarshal(byt, &got); err != nil {
+		t.Fatalf("unexpected unmarshaling error: %s", err)
+	}
+	if got.Value != NewUUID(testUUID) {
+		t.Errorf("expected value to be %s got %#v", testUUID, got.Value)
+	}
+}